@@ -21,8 +21,47 @@ type Input interface {
 	ClearError()
 }
 
+// TypedInput is an input that exchanges structured data rather than text, such as a checkbox
+// exchanging a bool. Typed inputs also exchange data as text, so that they work with values that
+// only handle text.
+type TypedInput interface {
+	Input
+	Get() any
+	Set(any)
+}
+
+// TypedValue is a value that exchanges structured data with a TypedInput, bypassing text.
+// Wrappers exchange data by implementing TypedValue too, checking the data before the value they
+// wrap stores it. Unless every value in the chain is typed, the input's text is used instead.
+type TypedValue interface {
+	Value
+	// Get returns the structured data.
+	Get() (any, error)
+	// Set validates and stores structured data.
+	Set(any) error
+}
+
+// Formatter is implemented by values that reformat text as it's typed, such as an input mask.
+type Formatter interface {
+	// Format reformats partial text, mapping the caret, a byte offset into text, to its offset in
+	// the result.
+	Format(text string, caret int) (string, int)
+}
+
+// Cursor is implemented by inputs that expose the caret as a byte offset into the text, such that
+// text reformatted in place keeps the caret beside the same character.
+type Cursor interface {
+	Cursor() int
+	SetCursor(int)
+}
+
 // Field binds a Value to an Input.
 type Field struct {
+	// Key identifies the field outside of the gui, for instance the name of a
+	// form parameter in an http request.
+	Key string
+	// Label describes the field to a person, for instance as a prompt.
+	Label string
 	Value Value
 	Input Input
 }
@@ -31,7 +70,12 @@ type Field struct {
 // Precise validation logic is implemented by the Valuer.
 // Returns a boolean indicating success.
 func (field *Field) Validate() bool {
-	err := field.Value.From(field.Input.Text())
+	var err error
+	if input, v, ok := field.typed(); ok {
+		err = v.Set(input.Get())
+	} else {
+		err = field.Value.From(field.Input.Text())
+	}
 	if err != nil {
 		field.Input.SetError(err.Error())
 	} else {
@@ -40,6 +84,81 @@ func (field *Field) Validate() bool {
 	return err == nil
 }
 
+// Normalize validates the field and, if valid, rewrites the input text from the value.
+// Call it when the input loses focus so that shorthand, such as a relative date, displays in full.
+// Returns a boolean indicating success.
+func (field *Field) Normalize() bool {
+	if !field.Validate() {
+		return false
+	}
+	if text, err := field.Value.To(); err == nil {
+		field.show(text)
+	}
+	return true
+}
+
+// show displays the value in the input, exchanging data directly with typed inputs.
+func (field *Field) show(text string) {
+	if input, v, ok := field.typed(); ok {
+		if data, err := v.Get(); err == nil {
+			input.Set(data)
+			return
+		}
+	}
+	field.Input.SetText(text)
+}
+
+// format reformats the input text in place if the value, or any value it wraps, is a Formatter.
+// Returns the text of the input.
+func (field *Field) format(text string) string {
+	for v := field.Value; v != nil; {
+		if formatter, ok := v.(Formatter); ok {
+			cursor, hasCursor := field.Input.(Cursor)
+			caret := len(text)
+			if hasCursor {
+				caret = cursor.Cursor()
+			}
+			formatted, caret := formatter.Format(text, caret)
+			if formatted != text {
+				field.Input.SetText(formatted)
+				if hasCursor {
+					cursor.SetCursor(caret)
+				}
+			}
+			return formatted
+		}
+		unwrapper, ok := v.(Unwrapper)
+		if !ok {
+			break
+		}
+		v = unwrapper.Unwrap()
+	}
+	return text
+}
+
+// typed reports whether both the input and the value, along with every value it wraps, exchange
+// structured data.
+func (field *Field) typed() (TypedInput, TypedValue, bool) {
+	input, ok := field.Input.(TypedInput)
+	if !ok {
+		return nil, nil, false
+	}
+	typed, ok := field.Value.(TypedValue)
+	if !ok {
+		return nil, nil, false
+	}
+	for v := Value(typed); ; {
+		if _, ok := v.(TypedValue); !ok {
+			return nil, nil, false
+		}
+		unwrapper, ok := v.(Unwrapper)
+		if !ok {
+			return input, typed, true
+		}
+		v = unwrapper.Unwrap()
+	}
+}
+
 // Form exercises field bindings.
 //
 // There's two primary ways of using a Form:
@@ -83,7 +202,7 @@ func (f *Form) Load(fields []Field) {
 		} else {
 			f.cache[ii] = field.Input.Text()
 			field.Input.ClearError()
-			field.Input.SetText(text)
+			field.show(text)
 		}
 	}
 }
@@ -109,7 +228,7 @@ func (f *Form) Validate() {
 			changed = f.cache[ii] != text
 		)
 		if changed {
-			f.cache[ii] = text
+			f.cache[ii] = field.format(text)
 			field.Validate()
 		}
 	}
@@ -120,7 +239,7 @@ func (f *Form) Clear() {
 		field.Value.Clear()
 		text, _ := field.Value.To()
 		field.Input.ClearError()
-		field.Input.SetText(text)
-		f.cache[ii] = text
+		field.show(text)
+		f.cache[ii] = field.Input.Text()
 	}
 }
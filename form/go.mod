@@ -1,8 +1,16 @@
 module git.sr.ht/~jackmordaunt/gio-planet/form
 
-go 1.16
+go 1.18
 
 require (
 	gioui.org v0.0.0-20210629070615-cf778ecd0640
 	gioui.org/x v0.0.0-20210615121216-b3d6aa6ed67b
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/text v0.22.0
+)
+
+require (
+	golang.org/x/exp v0.0.0-20201229011636-eab1b5eb1a03 // indirect
+	golang.org/x/image v0.0.0-20200927104501-e162460cd6b5 // indirect
+	golang.org/x/sys v0.0.0-20210304124612-50617c2ba197 // indirect
 )
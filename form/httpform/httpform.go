@@ -0,0 +1,80 @@
+// Package httpform binds forms to the form data of an http request, so that the same values that
+// validate a gui can validate a server.
+package httpform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// Input implements `form.Input` over a single form parameter.
+type Input struct {
+	text string
+	err  string
+}
+
+func (in *Input) Text() string {
+	return in.text
+}
+
+func (in *Input) SetText(text string) {
+	in.text = text
+}
+
+func (in *Input) SetError(err string) {
+	in.err = err
+}
+
+func (in *Input) ClearError() {
+	in.err = ""
+}
+
+// Error returns the validation error, if any.
+func (in *Input) Error() string {
+	return in.err
+}
+
+// Errors maps field keys to validation errors.
+type Errors map[string]string
+
+// WriteJSON responds with the errors encoded as a JSON object and a 422 status.
+func (errs Errors) WriteJSON(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	return json.NewEncoder(w).Encode(struct {
+		Errors Errors `json:"errors"`
+	}{Errors: errs})
+}
+
+// Submit loads the request form data into the form and batch validates it.
+//
+// Each field reads the form parameter named by its key. Fields without an `*Input` are given one.
+// The returned errors are empty when all fields validated, in which case the model is ready to use.
+func Submit(r *http.Request, f *form.Form) (Errors, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("parsing form: %w", err)
+	}
+	for ii := range f.Fields {
+		field := &f.Fields[ii]
+		if field.Key == "" {
+			return nil, fmt.Errorf("field %d: missing key", ii)
+		}
+		in, ok := field.Input.(*Input)
+		if !ok {
+			in = &Input{}
+			field.Input = in
+		}
+		in.SetText(r.Form.Get(field.Key))
+	}
+	f.Submit()
+	errs := Errors{}
+	for _, field := range f.Fields {
+		if err := field.Input.(*Input).Error(); err != "" {
+			errs[field.Key] = err
+		}
+	}
+	return errs, nil
+}
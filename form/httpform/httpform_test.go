@@ -0,0 +1,93 @@
+package httpform
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/value"
+)
+
+type Person struct {
+	Age  int
+	Name string
+}
+
+func fields(p *Person) []form.Field {
+	return []form.Field{
+		{
+			Key:   "age",
+			Value: value.Int{Value: &p.Age},
+		},
+		{
+			Key:   "name",
+			Value: value.Required{Value: value.Text{Value: &p.Name}},
+		},
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	for _, tt := range []struct {
+		Label string
+		Data  url.Values
+		Want  Errors
+		Model Person
+	}{
+		{
+			Label: "valid",
+			Data:  url.Values{"age": {"42"}, "name": {"Jack"}},
+			Want:  Errors{},
+			Model: Person{Age: 42, Name: "Jack"},
+		},
+		{
+			Label: "invalid",
+			Data:  url.Values{"age": {"forty"}},
+			Want: Errors{
+				"age":  "must be a valid number",
+				"name": "required",
+			},
+		},
+	} {
+		t.Run(tt.Label, func(t *testing.T) {
+			var (
+				p Person
+				f = form.Form{Fields: fields(&p)}
+				r = httptest.NewRequest("POST", "/", strings.NewReader(tt.Data.Encode()))
+			)
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			errs, err := Submit(r, &f)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.Want, errs) {
+				t.Fatalf("\nwant: %v\n got: %v", tt.Want, errs)
+			}
+			if len(errs) == 0 && !reflect.DeepEqual(tt.Model, p) {
+				t.Fatalf("\nwant: %+v\n got: %+v", tt.Model, p)
+			}
+		})
+	}
+}
+
+func TestErrorsWriteJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := (Errors{"age": "required"}).WriteJSON(w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Code != 422 {
+		t.Fatalf("want status 422, got %d", w.Code)
+	}
+	var body struct {
+		Errors map[string]string
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if body.Errors["age"] != "required" {
+		t.Fatalf("unexpected body: %+v", body)
+	}
+}
@@ -0,0 +1,160 @@
+// Package input adapts Gio's non-text widgets into typed form inputs, such that checkboxes,
+// radio groups and sliders live in the same form as text fields.
+//
+// Each input embeds its widget, so the input is laid out via the widget:
+//
+//	var subscribe input.Bool
+//	form.Field{Value: value.Bool{Value: &model.Subscribe}, Input: &subscribe}
+//	material.CheckBox(th, &subscribe.Bool, "Subscribe").Layout(gtx)
+//
+// Widgets have nowhere to display errors, so inputs hold the error for the caller to display.
+//
+// TextField adapts a text field such that values reformatting text as it's typed, such as
+// `value.Mask`, keep the caret in place.
+package input
+
+import (
+	"strconv"
+
+	"gioui.org/widget"
+	"gioui.org/x/component"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// Bool adapts a checkbox or switch.
+type Bool struct {
+	widget.Bool
+	err string
+}
+
+func (in *Bool) Get() any {
+	return in.Value
+}
+
+func (in *Bool) Set(data any) {
+	if b, ok := data.(bool); ok {
+		in.Value = b
+	}
+}
+
+func (in *Bool) Text() string {
+	if in.Value {
+		return "yes"
+	}
+	return "no"
+}
+
+func (in *Bool) SetText(text string) {
+	if b, err := parse.Bool(text); err == nil {
+		in.Value = b
+	}
+}
+
+func (in *Bool) SetError(err string) {
+	in.err = err
+}
+
+func (in *Bool) ClearError() {
+	in.err = ""
+}
+
+// Error returns the current error, if any.
+func (in *Bool) Error() string {
+	return in.err
+}
+
+// Enum adapts a radio group, whose buttons are keyed by the key of each option.
+type Enum struct {
+	widget.Enum
+	err string
+}
+
+func (in *Enum) Get() any {
+	return in.Value
+}
+
+func (in *Enum) Set(data any) {
+	if key, ok := data.(string); ok {
+		in.Value = key
+	}
+}
+
+func (in *Enum) Text() string {
+	return in.Value
+}
+
+func (in *Enum) SetText(text string) {
+	in.Value = text
+}
+
+func (in *Enum) SetError(err string) {
+	in.err = err
+}
+
+func (in *Enum) ClearError() {
+	in.err = ""
+}
+
+// Error returns the current error, if any.
+func (in *Enum) Error() string {
+	return in.err
+}
+
+// Float adapts a slider.
+type Float struct {
+	widget.Float
+	err string
+}
+
+func (in *Float) Get() any {
+	return in.Value
+}
+
+func (in *Float) Set(data any) {
+	switch n := data.(type) {
+	case float64:
+		in.Value = float32(n)
+	case float32:
+		in.Value = n
+	case int:
+		in.Value = float32(n)
+	}
+}
+
+func (in *Float) Text() string {
+	return strconv.FormatFloat(float64(in.Value), 'f', -1, 32)
+}
+
+func (in *Float) SetText(text string) {
+	if n, err := parse.DefaultLocale.ParseFloat(text); err == nil {
+		in.Value = float32(n)
+	}
+}
+
+func (in *Float) SetError(err string) {
+	in.err = err
+}
+
+func (in *Float) ClearError() {
+	in.err = ""
+}
+
+// Error returns the current error, if any.
+func (in *Float) Error() string {
+	return in.err
+}
+
+// TextField adapts a text field, exposing its caret.
+type TextField struct {
+	component.TextField
+}
+
+func (in *TextField) Cursor() int {
+	start, _ := in.Selection()
+	return start
+}
+
+func (in *TextField) SetCursor(caret int) {
+	in.SetCaret(caret, caret)
+}
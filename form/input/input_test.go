@@ -0,0 +1,147 @@
+package input
+
+import (
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/value"
+)
+
+type priority int
+
+func TestTypedForm(t *testing.T) {
+	var (
+		model struct {
+			Subscribe bool
+			Priority  priority
+			Volume    int
+			Ratio     float64
+		}
+		inputs struct {
+			Subscribe Bool
+			Priority  Enum
+			Volume    Float
+			Ratio     Float
+		}
+		f form.Form
+	)
+	model.Subscribe = true
+	model.Priority = 2
+	f.Load([]form.Field{
+		{Value: value.Bool{Value: &model.Subscribe}, Input: &inputs.Subscribe},
+		{
+			Value: value.Required{Value: value.Enum[priority]{Value: &model.Priority, Options: []value.Option[priority]{
+				{Value: 1, Label: "Low", Key: "low"},
+				{Value: 2, Label: "High", Key: "high"},
+			}}},
+			Input: &inputs.Priority,
+		},
+		{Value: value.Max{Value: value.Int{Value: &model.Volume}, Max: 10}, Input: &inputs.Volume},
+		{Value: value.Float{Value: &model.Ratio}, Input: &inputs.Ratio},
+	})
+	if !inputs.Subscribe.Value || inputs.Priority.Value != "high" {
+		t.Fatalf("want model loaded into widgets, got %v, %q", inputs.Subscribe.Value, inputs.Priority.Value)
+	}
+	inputs.Subscribe.Value = false
+	inputs.Priority.Value = "low"
+	inputs.Volume.Value = 7.6
+	inputs.Ratio.Value = 0.3
+	if !f.Submit() {
+		t.Fatalf("unexpected errors: %q, %q", inputs.Priority.Error(), inputs.Volume.Error())
+	}
+	if model.Subscribe || model.Priority != 1 || model.Volume != 8 || model.Ratio != 0.3 {
+		t.Fatalf("want widgets stored into model, got %+v", model)
+	}
+	// Wrapped values check the stored data.
+	inputs.Volume.Value = 11
+	inputs.Priority.Value = ""
+	if f.Submit() {
+		t.Fatalf("want errors, got none")
+	}
+	if got := inputs.Volume.Error(); got != "must be at most 10" {
+		t.Fatalf("want %q, got %q", "must be at most 10", got)
+	}
+	if got := inputs.Priority.Error(); got != "required" {
+		t.Fatalf("want %q, got %q", "required", got)
+	}
+}
+
+func TestTypedStore(t *testing.T) {
+	var (
+		model struct {
+			Count int
+			Level int
+			Ratio float64
+		}
+		inputs struct {
+			Count, Level, Ratio Float
+		}
+		f form.Form
+	)
+	model.Level = 4
+	f.Load([]form.Field{
+		{Value: value.Int{Value: &model.Count, Default: 5}, Input: &inputs.Count},
+		{Value: value.Max{Value: value.Int{Value: &model.Level}, Max: 10}, Input: &inputs.Level},
+		{Value: value.Float{Value: &model.Ratio}, Input: &inputs.Ratio},
+	})
+	inputs.Count.Value = 0
+	inputs.Level.Value = 20
+	inputs.Ratio.Value = 0.125
+	if f.Submit() {
+		t.Fatalf("want errors, got none")
+	}
+	if got := inputs.Level.Error(); got != "must be at most 10" {
+		t.Fatalf("want %q, got %q", "must be at most 10", got)
+	}
+	if model.Count != 0 {
+		t.Fatalf("want zero stored despite the default, got %d", model.Count)
+	}
+	if model.Level != 4 {
+		t.Fatalf("want rejected data left unstored, got %d", model.Level)
+	}
+	if model.Ratio != 0.125 {
+		t.Fatalf("want float stored exactly, got %v", model.Ratio)
+	}
+}
+
+func TestTextFallback(t *testing.T) {
+	var (
+		answer string
+		in     Bool
+		f      form.Form
+	)
+	fields := []form.Field{{Value: value.Text{Value: &answer}, Input: &in}}
+	f.Load(fields)
+	in.Value = true
+	if !f.Submit() || answer != "yes" {
+		t.Fatalf("want %q, got %q", "yes", answer)
+	}
+	answer = "no"
+	f.Load(fields)
+	if in.Value {
+		t.Fatalf("want text loaded into widget")
+	}
+}
+
+func TestTextFieldMask(t *testing.T) {
+	var (
+		phone string
+		in    TextField
+		f     form.Form
+	)
+	f.Load([]form.Field{{Value: value.Required{Value: value.Mask{Value: &phone, Pattern: "(999) 999-9999"}}, Input: &in}})
+	in.SetText("5551")
+	in.SetCursor(4)
+	f.Validate()
+	if got := in.Text(); got != "(555) 1" {
+		t.Fatalf("want text reformatted as typed, got %q", got)
+	}
+	if got := in.Cursor(); got != len("(555) 1") {
+		t.Fatalf("want caret after the typed digit, got %d", got)
+	}
+	in.SetText("(555) 1234567")
+	in.SetCursor(len("(555) 1234567"))
+	if f.Validate(); phone != "5551234567" || in.Text() != "(555) 123-4567" {
+		t.Fatalf("want raw digits stored, got %q displayed as %q", phone, in.Text())
+	}
+}
@@ -0,0 +1,197 @@
+// Package money implements exact amounts of money in ISO 4217 currencies.
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// Currency describes an ISO 4217 currency.
+type Currency struct {
+	// Code is the three letter currency code, such as "USD".
+	Code string
+	// Symbol is the customary symbol, such as "$".
+	Symbol string
+	// Minor is the number of decimal places in the minor unit, such as 2 for cents.
+	Minor int
+}
+
+// currencies is a table of common currencies by code.
+var currencies = map[string]Currency{
+	"AUD": {Code: "AUD", Symbol: "A$", Minor: 2},
+	"BHD": {Code: "BHD", Symbol: "BD", Minor: 3},
+	"BRL": {Code: "BRL", Symbol: "R$", Minor: 2},
+	"CAD": {Code: "CAD", Symbol: "CA$", Minor: 2},
+	"CHF": {Code: "CHF", Symbol: "CHF", Minor: 2},
+	"CLP": {Code: "CLP", Symbol: "CLP$", Minor: 0},
+	"CNY": {Code: "CNY", Symbol: "CN¥", Minor: 2},
+	"CZK": {Code: "CZK", Symbol: "Kč", Minor: 2},
+	"DKK": {Code: "DKK", Symbol: "kr.", Minor: 2},
+	"EUR": {Code: "EUR", Symbol: "€", Minor: 2},
+	"GBP": {Code: "GBP", Symbol: "£", Minor: 2},
+	"HKD": {Code: "HKD", Symbol: "HK$", Minor: 2},
+	"HUF": {Code: "HUF", Symbol: "Ft", Minor: 2},
+	"IDR": {Code: "IDR", Symbol: "Rp", Minor: 2},
+	"ILS": {Code: "ILS", Symbol: "₪", Minor: 2},
+	"INR": {Code: "INR", Symbol: "₹", Minor: 2},
+	"ISK": {Code: "ISK", Symbol: "ISK", Minor: 0},
+	"JOD": {Code: "JOD", Symbol: "JD", Minor: 3},
+	"JPY": {Code: "JPY", Symbol: "¥", Minor: 0},
+	"KRW": {Code: "KRW", Symbol: "₩", Minor: 0},
+	"KWD": {Code: "KWD", Symbol: "KD", Minor: 3},
+	"MXN": {Code: "MXN", Symbol: "MX$", Minor: 2},
+	"NOK": {Code: "NOK", Symbol: "kr", Minor: 2},
+	"NZD": {Code: "NZD", Symbol: "NZ$", Minor: 2},
+	"OMR": {Code: "OMR", Symbol: "OMR", Minor: 3},
+	"PLN": {Code: "PLN", Symbol: "zł", Minor: 2},
+	"RUB": {Code: "RUB", Symbol: "₽", Minor: 2},
+	"SEK": {Code: "SEK", Symbol: "kr", Minor: 2},
+	"SGD": {Code: "SGD", Symbol: "S$", Minor: 2},
+	"THB": {Code: "THB", Symbol: "฿", Minor: 2},
+	"TND": {Code: "TND", Symbol: "DT", Minor: 3},
+	"TRY": {Code: "TRY", Symbol: "₺", Minor: 2},
+	"USD": {Code: "USD", Symbol: "$", Minor: 2},
+	"VND": {Code: "VND", Symbol: "₫", Minor: 0},
+	"ZAR": {Code: "ZAR", Symbol: "R", Minor: 2},
+}
+
+// Lookup finds a currency by code.
+func Lookup(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// Amount is an exact amount of money, counted in the minor unit of the currency.
+// Amounts in the same currency can be totalled by adding the minor units.
+type Amount struct {
+	Minor    int64  `json:"minor"`
+	Currency string `json:"currency"`
+}
+
+// Rat returns the amount in major units, such as dollars rather than cents.
+func (a Amount) Rat() *big.Rat {
+	c, _ := Lookup(a.Currency)
+	return new(big.Rat).SetFrac(big.NewInt(a.Minor), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.Minor)), nil))
+}
+
+// String formats the amount with the currency code, such as "USD 1234.50".
+func (a Amount) String() string {
+	c, _ := Lookup(a.Currency)
+	return fmt.Sprintf("%s %s", a.Currency, a.Rat().FloatString(c.Minor))
+}
+
+// Format writes the amount as written in the locale, with the currency symbol.
+func (a Amount) Format(l parse.Locale) string {
+	c, ok := Lookup(a.Currency)
+	if !ok {
+		return a.String()
+	}
+	var (
+		r       = a.Rat()
+		pattern = l.Currency
+		sign    string
+	)
+	if r.Sign() < 0 {
+		sign = l.Minus
+		r.Neg(r)
+	}
+	if pattern == "" {
+		pattern = parse.DefaultLocale.Currency
+	}
+	text := strings.NewReplacer("¤", c.Symbol, "#", l.FormatDecimal(r, c.Minor, true)).Replace(pattern)
+	return sign + text
+}
+
+// Parse reads an amount written in the locale.
+//
+// The currency is named by a symbol or code before or after the number, such as "$12.50",
+// "12,50 €" or "12.50 USD", otherwise the fallback currency is assumed. Symbols that several
+// currencies share, such as "$", are read as the fallback where it matches. The number must not
+// have more decimal places than the currency's minor unit.
+func Parse(s string, l parse.Locale, fallback string) (Amount, error) {
+	s = strings.TrimFunc(s, unicode.IsSpace)
+	var negative bool
+	for _, minus := range []string{l.Minus, "-", "−"} {
+		if minus != "" && strings.HasPrefix(s, minus) {
+			negative, s = true, strings.TrimFunc(s[len(minus):], unicode.IsSpace)
+			break
+		}
+	}
+	text, code, err := currency(s, fallback)
+	if err != nil {
+		return Amount{}, err
+	}
+	c, ok := Lookup(code)
+	if !ok {
+		return Amount{}, fmt.Errorf("must name a currency")
+	}
+	r, err := l.ParseDecimal(text)
+	if err != nil {
+		return Amount{}, err
+	}
+	if negative {
+		r.Neg(r)
+	}
+	minor := new(big.Rat).Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.Minor)), nil)))
+	if !minor.IsInt() {
+		if c.Minor == 0 {
+			return Amount{}, fmt.Errorf("must be a whole amount of %s", c.Code)
+		}
+		return Amount{}, fmt.Errorf("must have at most %d decimal places for %s", c.Minor, c.Code)
+	}
+	if !minor.Num().IsInt64() {
+		return Amount{}, fmt.Errorf("must be a smaller amount")
+	}
+	return Amount{Minor: minor.Num().Int64(), Currency: c.Code}, nil
+}
+
+// currency strips a leading or trailing currency code or symbol from s, returning the remaining
+// number and the currency code. The longest matching code or symbol wins, so "CLP$5" reads as
+// the symbol "CLP$" rather than the code "CLP".
+func currency(s, fallback string) (string, string, error) {
+	var (
+		matches []string
+		number  string
+	)
+	strip := func(affix string) (string, bool) {
+		if len(s) < len(affix) {
+			return "", false
+		}
+		switch {
+		case strings.EqualFold(s[:len(affix)], affix):
+			return s[len(affix):], true
+		case strings.EqualFold(s[len(s)-len(affix):], affix):
+			return s[:len(s)-len(affix)], true
+		}
+		return "", false
+	}
+	for code, c := range currencies {
+		for _, affix := range []string{code, c.Symbol} {
+			rest, ok := strip(affix)
+			switch {
+			case !ok:
+			case len(matches) == 0 || len(rest) < len(number):
+				matches, number = []string{code}, rest
+			case len(rest) == len(number) && matches[len(matches)-1] != code:
+				matches = append(matches, code)
+			}
+		}
+	}
+	number = strings.TrimFunc(number, unicode.IsSpace)
+	switch {
+	case len(matches) == 0:
+		return s, strings.ToUpper(fallback), nil
+	case len(matches) == 1:
+		return number, matches[0], nil
+	}
+	for _, code := range matches {
+		if code == strings.ToUpper(fallback) {
+			return number, code, nil
+		}
+	}
+	return "", "", fmt.Errorf("must name the currency by code")
+}
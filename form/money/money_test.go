@@ -0,0 +1,85 @@
+package money
+
+import (
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+func TestParse(t *testing.T) {
+	de, _ := parse.LookupLocale("de")
+	for _, tt := range []struct {
+		Text     string
+		Locale   parse.Locale
+		Fallback string
+		Want     Amount
+		Err      string
+	}{
+		{Text: "12.50", Locale: parse.DefaultLocale, Fallback: "USD", Want: Amount{1250, "USD"}},
+		{Text: "$1,234.56", Locale: parse.DefaultLocale, Want: Amount{123456, "USD"}},
+		{Text: "A$5", Locale: parse.DefaultLocale, Want: Amount{500, "AUD"}},
+		{Text: "12.50 eur", Locale: parse.DefaultLocale, Want: Amount{1250, "EUR"}},
+		{Text: "1.234,5 €", Locale: de, Want: Amount{123450, "EUR"}},
+		{Text: "-¥1,000", Locale: parse.DefaultLocale, Want: Amount{-1000, "JPY"}},
+		{Text: "KWD 1.125", Locale: parse.DefaultLocale, Want: Amount{1125, "KWD"}},
+		{Text: "100 kr", Locale: parse.DefaultLocale, Fallback: "SEK", Want: Amount{10000, "SEK"}},
+		{Text: "100 kr", Locale: parse.DefaultLocale, Err: "must name the currency by code"},
+		{Text: "1.005", Locale: parse.DefaultLocale, Fallback: "USD", Err: "must have at most 2 decimal places for USD"},
+		{Text: "¥1.5", Locale: parse.DefaultLocale, Err: "must be a whole amount of JPY"},
+		{Text: "12", Locale: parse.DefaultLocale, Err: "must name a currency"},
+	} {
+		got, err := Parse(tt.Text, tt.Locale, tt.Fallback)
+		if tt.Err != "" {
+			if err == nil || err.Error() != tt.Err {
+				t.Fatalf("%q: want error %q, got %v", tt.Text, tt.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.Text, err)
+		}
+		if got != tt.Want {
+			t.Fatalf("%q: want %v, got %v", tt.Text, tt.Want, got)
+		}
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	de, _ := parse.LookupLocale("de")
+	for _, tt := range []struct {
+		Amount Amount
+		Locale parse.Locale
+		Want   string
+	}{
+		{Amount{123456, "USD"}, parse.DefaultLocale, "$1,234.56"},
+		{Amount{-5, "USD"}, parse.DefaultLocale, "-$0.05"},
+		{Amount{123450, "EUR"}, de, "1.234,50 €"},
+		{Amount{1000, "JPY"}, parse.DefaultLocale, "¥1,000"},
+		{Amount{5000, "CLP"}, parse.DefaultLocale, "CLP$5,000"},
+	} {
+		text := tt.Amount.Format(tt.Locale)
+		if text != tt.Want {
+			t.Fatalf("want %q, got %q", tt.Want, text)
+		}
+		got, err := Parse(text, tt.Locale, "")
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if got != tt.Amount {
+			t.Fatalf("%q: want %v, got %v", text, tt.Amount, got)
+		}
+	}
+	for code := range currencies {
+		for _, l := range []parse.Locale{parse.DefaultLocale, de} {
+			want := Amount{-123456, code}
+			text := want.Format(l)
+			got, err := Parse(text, l, code)
+			if err != nil {
+				t.Fatalf("%q: unexpected error: %v", text, err)
+			}
+			if got != want {
+				t.Fatalf("%q: want %v, got %v", text, want, got)
+			}
+		}
+	}
+}
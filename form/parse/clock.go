@@ -0,0 +1,141 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOfDay parses a time of day on a 12 or 24 hour clock, such as "3pm", "3:30 p.m.", "15:00",
+// "1500", "15:00:30", "noon" or "midnight", returning the time since midnight.
+//
+// Errors naming a component are a `DateError`.
+func TimeOfDay(s string) (time.Duration, error) {
+	var (
+		text    = strings.ToLower(strings.Join(strings.Fields(s), ""))
+		invalid = fmt.Errorf(`must be a time such as "3pm" or "15:00"`)
+		period  string
+	)
+	switch text {
+	case "noon", "midday":
+		return 12 * time.Hour, nil
+	case "midnight":
+		return 0, nil
+	}
+	for _, suffix := range []string{"a.m.", "p.m.", "am", "pm", "a", "p"} {
+		if strings.HasSuffix(text, suffix) {
+			text, period = strings.TrimSuffix(text, suffix), suffix[:1]
+			break
+		}
+	}
+	parts := strings.Split(strings.ReplaceAll(text, ".", ":"), ":")
+	if len(parts) == 1 && len(parts[0]) > 2 {
+		// Digits without a separator, such as "930" or "1530".
+		digits := parts[0]
+		parts = []string{digits[:len(digits)-2], digits[len(digits)-2:]}
+	}
+	if len(parts) > 3 || parts[0] == "" {
+		return 0, invalid
+	}
+	var values [3]int
+	for ii, part := range parts {
+		name := []string{"hour", "minute", "second"}[ii]
+		n, err := strconv.Atoi(part)
+		if err != nil || strings.ContainsAny(part, "+-") {
+			return 0, DateError{Component: name, Text: part, Reason: "not a number"}
+		}
+		if ii > 0 && (len(part) != 2 || n > 59) {
+			return 0, DateError{Component: name, Text: part, Reason: "must be between 00 and 59"}
+		}
+		values[ii] = n
+	}
+	hour := values[0]
+	switch {
+	case period != "" && (hour < 1 || hour > 12):
+		return 0, DateError{Component: "hour", Text: parts[0], Reason: "must be between 1 and 12"}
+	case period == "" && hour > 23:
+		return 0, DateError{Component: "hour", Text: parts[0], Reason: "must be between 0 and 23"}
+	case period == "a" && hour == 12:
+		hour = 0
+	case period == "p" && hour != 12:
+		hour += 12
+	}
+	return time.Duration(hour)*time.Hour +
+		time.Duration(values[1])*time.Minute +
+		time.Duration(values[2])*time.Second, nil
+}
+
+// FormatTimeOfDay formats the time since midnight on a 12 hour clock, such as "3:30 PM", or a 24
+// hour clock, such as "15:30". Seconds are included when not zero.
+func FormatTimeOfDay(d time.Duration, clock12 bool) string {
+	d %= 24 * time.Hour
+	var (
+		hour    = int(d / time.Hour)
+		minute  = int(d % time.Hour / time.Minute)
+		second  = int(d % time.Minute / time.Second)
+		seconds string
+	)
+	if second != 0 {
+		seconds = fmt.Sprintf(":%02d", second)
+	}
+	if !clock12 {
+		return fmt.Sprintf("%02d:%02d%s", hour, minute, seconds)
+	}
+	period := "AM"
+	if hour >= 12 {
+		period = "PM"
+	}
+	if hour = hour % 12; hour == 0 {
+		hour = 12
+	}
+	return fmt.Sprintf("%d:%02d%s %s", hour, minute, seconds, period)
+}
+
+// Location parses an IANA time zone name, such as "Australia/Sydney" or "UTC", validated against
+// the time zone database.
+//
+// Import package `tzdata` in package main to embed the database, otherwise the zones accepted
+// depend on the host and none but "UTC" are accepted on hosts without one.
+func Location(s string) (*time.Location, error) {
+	name := strings.TrimSpace(s)
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf(`must be a time zone such as "Europe/Berlin"`)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf(`must be a time zone such as "Europe/Berlin"`)
+	}
+	return loc, nil
+}
+
+// Zone parses an explicit time zone written after a time, either a zone name such as
+// "Europe/Berlin", "UTC" or "Z", or a fixed offset such as "+10:00" or "-0530".
+func Zone(s string) (*time.Location, error) {
+	switch s {
+	case "Z", "z":
+		return time.UTC, nil
+	}
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		digits := s[1:]
+		if len(digits) == 5 && digits[2] == ':' {
+			digits = digits[:2] + digits[3:]
+		} else if len(digits) == 2 {
+			digits += "00"
+		}
+		if len(digits) != 4 || !isDigits(digits) {
+			return nil, fmt.Errorf("zone offset must be +hh:mm: %s", s)
+		}
+		hh, _ := strconv.Atoi(digits[:2])
+		mm, _ := strconv.Atoi(digits[2:])
+		if hh > 14 || mm > 59 {
+			return nil, fmt.Errorf("zone offset must be +hh:mm: %s", s)
+		}
+		offset := hh*3600 + mm*60
+		if s[0] == '-' {
+			offset = -offset
+		}
+		return time.FixedZone(s, offset), nil
+	}
+	return Location(s)
+}
@@ -0,0 +1,85 @@
+package parse
+
+import (
+	"testing"
+	"time"
+
+	_ "git.sr.ht/~jackmordaunt/gio-planet/form/parse/tzdata"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	h, m := time.Hour, time.Minute
+	for text, want := range map[string]time.Duration{
+		"3pm":       15 * h,
+		"3 PM":      15 * h,
+		"3:30 p.m.": 15*h + 30*m,
+		"12am":      0,
+		"12pm":      12 * h,
+		"9.15a":     9*h + 15*m,
+		"15:00":     15 * h,
+		"1530":      15*h + 30*m,
+		"930":       9*h + 30*m,
+		"0:05":      5 * m,
+		"23:59:30":  23*h + 59*m + 30*time.Second,
+		"noon":      12 * h,
+		"midnight":  0,
+		"  7 : 45 ": 7*h + 45*m,
+	} {
+		got, err := TimeOfDay(text)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if got != want {
+			t.Fatalf("%q: want %v, got %v", text, want, got)
+		}
+	}
+	for text, want := range map[string]string{
+		"13pm":  "hour must be between 1 and 12: 13",
+		"24:00": "hour must be between 0 and 23: 24",
+		"3:60":  "minute must be between 00 and 59: 60",
+		"3:5":   "minute must be between 00 and 59: 5",
+		"x:00":  "hour not a number: x",
+		"":      `must be a time such as "3pm" or "15:00"`,
+	} {
+		if _, err := TimeOfDay(text); err == nil || err.Error() != want {
+			t.Fatalf("%q: want error %q, got %v", text, want, err)
+		}
+	}
+}
+
+func TestFormatTimeOfDay(t *testing.T) {
+	d := 15*time.Hour + 4*time.Minute
+	if got := FormatTimeOfDay(d, false); got != "15:04" {
+		t.Fatalf("want %q, got %q", "15:04", got)
+	}
+	if got := FormatTimeOfDay(d, true); got != "3:04 PM" {
+		t.Fatalf("want %q, got %q", "3:04 PM", got)
+	}
+	if got := FormatTimeOfDay(30*time.Second, true); got != "12:00:30 AM" {
+		t.Fatalf("want %q, got %q", "12:00:30 AM", got)
+	}
+}
+
+func TestZone(t *testing.T) {
+	for text, offset := range map[string]int{
+		"UTC":             0,
+		"Z":               0,
+		"+10:00":          10 * 3600,
+		"-0530":           -(5*3600 + 30*60),
+		"+02":             2 * 3600,
+		"Australia/Perth": 8 * 3600,
+	} {
+		loc, err := Zone(text)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if _, got := time.Date(2021, 6, 1, 0, 0, 0, 0, loc).Zone(); got != offset {
+			t.Fatalf("%q: want offset %d, got %d", text, offset, got)
+		}
+	}
+	for _, text := range []string{"Mars/Olympus", "+25:00", "+1:0", "Local", ""} {
+		if _, err := Zone(text); err == nil {
+			t.Fatalf("%q: want error", text)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date layouts are written with the tokens "d", "dd", "m", "mm", "yy" and "yyyy", where the short
+// forms display without zero padding. Any other character matches literally.
+//
+// Days and months accept one or two digits, years exactly the digits of the token.
+const (
+	DMY      = "d/m/yyyy"
+	MDY      = "m/d/yyyy"
+	ISO      = "yyyy-mm-dd"
+	DMYDots  = "d.m.yyyy"
+	DMYShort = "d/m/yy"
+)
+
+// DateError reports which component of a date or time is wrong.
+type DateError struct {
+	// Component is "day", "month", "year", "hour", "minute" or "second".
+	Component string
+	// Text is the offending component as written.
+	Text string
+	// Reason describes the problem.
+	Reason string
+}
+
+func (err DateError) Error() string {
+	return fmt.Sprintf("%s %s: %s", err.Component, err.Reason, err.Text)
+}
+
+// DateParser parses dates strictly, rejecting impossible dates such as the 31st of February
+// rather than normalizing them into the following month.
+type DateParser struct {
+	// Layouts are attempted in order. Defaults to `DMY`.
+	Layouts []string
+	// Pivot decides the century of two digit years: years below the pivot are in the 2000s and
+	// the rest in the 1900s. Defaults to 70.
+	Pivot int
+	// Location of the parsed date. Defaults to `time.Local`.
+	Location *time.Location
+}
+
+// Parse a date matching any of the layouts.
+//
+// If the text matches the shape of a layout but a component is wrong, the returned error is a
+// `DateError` naming the component.
+func (p DateParser) Parse(s string) (time.Time, error) {
+	var (
+		layouts = p.Layouts
+		best    error
+		score   = -1
+	)
+	if len(layouts) == 0 {
+		layouts = []string{DMY}
+	}
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		t, matched, err := p.parse(s, layout)
+		if err == nil {
+			return t, nil
+		}
+		if _, ok := err.(DateError); ok && matched > score {
+			best, score = err, matched
+		}
+	}
+	if best != nil {
+		return time.Time{}, best
+	}
+	hints := make([]string, len(layouts))
+	for ii, layout := range layouts {
+		hints[ii] = Hint(layout)
+	}
+	return time.Time{}, fmt.Errorf("must be %s", strings.Join(hints, " or "))
+}
+
+// Hint describes a layout to a person, padding the short tokens such that "d/m/yyyy" reads
+// "dd/mm/yyyy".
+func Hint(layout string) string {
+	var b strings.Builder
+	for _, tok := range tokenize(layout) {
+		switch tok {
+		case "d":
+			b.WriteString("dd")
+		case "m":
+			b.WriteString("mm")
+		default:
+			b.WriteString(tok)
+		}
+	}
+	return b.String()
+}
+
+// parse s against a single layout, returning the number of components that parsed so that the
+// most relevant error can be reported.
+func (p DateParser) parse(s, layout string) (t time.Time, matched int, err error) {
+	var (
+		tokens           = tokenize(layout)
+		day, month, year int
+		dayText          string
+		shape            = fmt.Errorf("must be %s", Hint(layout))
+	)
+	for ii, tok := range tokens {
+		component, width := component(tok)
+		if component == "" {
+			if !strings.HasPrefix(s, tok) {
+				return t, matched, shape
+			}
+			s = s[len(tok):]
+			continue
+		}
+		// Take the text up to the next literal, or exactly the width of the token when
+		// components are adjacent.
+		var text string
+		switch next := ii + 1; {
+		case next == len(tokens):
+			text, s = s, ""
+		case isComponent(tokens[next]):
+			if len(s) < width {
+				return t, matched, shape
+			}
+			text, s = s[:width], s[width:]
+		default:
+			end := strings.Index(s, tokens[next])
+			if end < 0 {
+				return t, matched, shape
+			}
+			text, s = s[:end], s[end:]
+		}
+		n, err := strconv.Atoi(text)
+		if err != nil || strings.ContainsAny(text, "+-") {
+			return t, matched, DateError{Component: component, Text: text, Reason: "not a number"}
+		}
+		switch component {
+		case "day":
+			if len(text) > 2 || n < 1 || n > 31 {
+				return t, matched, DateError{Component: component, Text: text, Reason: "must be between 1 and 31"}
+			}
+			day, dayText = n, text
+		case "month":
+			if len(text) > 2 || n < 1 || n > 12 {
+				return t, matched, DateError{Component: component, Text: text, Reason: "must be between 1 and 12"}
+			}
+			month = n
+		case "year":
+			if len(text) != width {
+				return t, matched, DateError{Component: component, Text: text, Reason: fmt.Sprintf("must have %d digits", width)}
+			}
+			year = n
+			if width == 2 {
+				year = p.century(n)
+			}
+		}
+		matched++
+	}
+	if s != "" {
+		return t, matched, shape
+	}
+	if days := daysIn(time.Month(month), year); day > days {
+		return t, matched, DateError{
+			Component: "day",
+			Text:      dayText,
+			Reason:    fmt.Sprintf("must be between 1 and %d for %s %d", days, time.Month(month), year),
+		}
+	}
+	loc := p.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc), matched, nil
+}
+
+// century expands a two digit year around the pivot.
+func (p DateParser) century(yy int) int {
+	pivot := p.Pivot
+	if pivot == 0 {
+		pivot = 70
+	}
+	if yy < pivot {
+		return 2000 + yy
+	}
+	return 1900 + yy
+}
+
+// FormatDate formats a date according to a layout.
+func FormatDate(t time.Time, layout string) string {
+	var b strings.Builder
+	for _, tok := range tokenize(layout) {
+		switch tok {
+		case "d":
+			b.WriteString(strconv.Itoa(t.Day()))
+		case "dd":
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case "m":
+			b.WriteString(strconv.Itoa(int(t.Month())))
+		case "mm":
+			fmt.Fprintf(&b, "%02d", int(t.Month()))
+		case "yy":
+			fmt.Fprintf(&b, "%02d", t.Year()%100)
+		case "yyyy":
+			fmt.Fprintf(&b, "%04d", t.Year())
+		default:
+			b.WriteString(tok)
+		}
+	}
+	return b.String()
+}
+
+// tokenize splits a layout into component tokens and literal characters.
+func tokenize(layout string) []string {
+	var tokens []string
+	for layout != "" {
+		switch {
+		case strings.HasPrefix(layout, "yyyy"):
+			tokens, layout = append(tokens, "yyyy"), layout[4:]
+		case strings.HasPrefix(layout, "yy"),
+			strings.HasPrefix(layout, "dd"),
+			strings.HasPrefix(layout, "mm"):
+			tokens, layout = append(tokens, layout[:2]), layout[2:]
+		case layout[0] == 'd' || layout[0] == 'm':
+			tokens, layout = append(tokens, layout[:1]), layout[1:]
+		default:
+			n := len(string([]rune(layout)[0]))
+			if len(tokens) > 0 && !isComponent(tokens[len(tokens)-1]) {
+				tokens[len(tokens)-1] += layout[:n]
+			} else {
+				tokens = append(tokens, layout[:n])
+			}
+			layout = layout[n:]
+		}
+	}
+	return tokens
+}
+
+// component names the date component of a token and its width in digits.
+func component(tok string) (string, int) {
+	switch tok {
+	case "d", "dd":
+		return "day", 2
+	case "m", "mm":
+		return "month", 2
+	case "yy":
+		return "year", 2
+	case "yyyy":
+		return "year", 4
+	}
+	return "", 0
+}
+
+func isComponent(tok string) bool {
+	c, _ := component(tok)
+	return c != ""
+}
+
+// daysIn counts the days in a month.
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,74 @@
+package parse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateParser(t *testing.T) {
+	all := []string{ISO, DMY, DMYDots, DMYShort}
+	for _, tt := range []struct {
+		Layouts []string
+		Text    string
+		Want    time.Time
+		Err     string
+	}{
+		{Text: "2/1/2021", Want: date(2021, 1, 2)},
+		{Text: "02/01/2021", Want: date(2021, 1, 2)},
+		{Text: "29/2/2020", Want: date(2020, 2, 29)},
+		{Text: "31/02/2021", Err: "day must be between 1 and 28 for February 2021: 31"},
+		{Text: "29/2/2021", Err: "day must be between 1 and 28 for February 2021: 29"},
+		{Text: "1/13/2021", Err: "month must be between 1 and 12: 13"},
+		{Text: "x/1/2021", Err: "day not a number: x"},
+		{Text: "1/x/2021", Err: "month not a number: x"},
+		{Text: "1/1/21", Err: "year must have 4 digits: 21"},
+		{Text: "1-1-2021", Err: "must be dd/mm/yyyy"},
+		{Text: "1/1/2021/", Err: "year not a number: 2021/"},
+		{Layouts: all, Text: "2021-03-15", Want: date(2021, 3, 15)},
+		{Layouts: all, Text: "15.3.2021", Want: date(2021, 3, 15)},
+		{Layouts: all, Text: "15/3/21", Want: date(2021, 3, 15)},
+		{Layouts: all, Text: "15/3/85", Want: date(1985, 3, 15)},
+		{Layouts: all, Text: "2021-02-30", Err: "day must be between 1 and 28 for February 2021: 30"},
+		{Layouts: all, Text: "March", Err: "must be yyyy-mm-dd or dd/mm/yyyy or dd.mm.yyyy or dd/mm/yy"},
+		{Layouts: []string{MDY}, Text: "3/15/2021", Want: date(2021, 3, 15)},
+		{Layouts: []string{"yyyymmdd"}, Text: "20210315", Want: date(2021, 3, 15)},
+	} {
+		got, err := DateParser{Layouts: tt.Layouts}.Parse(tt.Text)
+		if tt.Err != "" {
+			if err == nil || err.Error() != tt.Err {
+				t.Fatalf("%q: want error %q, got %v", tt.Text, tt.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.Text, err)
+		}
+		if !got.Equal(tt.Want) {
+			t.Fatalf("%q: want %v, got %v", tt.Text, tt.Want, got)
+		}
+	}
+}
+
+func TestDateErrorComponent(t *testing.T) {
+	_, err := Date("30/2/2021")
+	if derr, ok := err.(DateError); !ok || derr.Component != "day" {
+		t.Fatalf("want day error, got %#v", err)
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	d := date(2021, 3, 5)
+	for layout, want := range map[string]string{
+		DMY:        "5/3/2021",
+		ISO:        "2021-03-05",
+		"dd.mm.yy": "05.03.21",
+	} {
+		if got := FormatDate(d, layout); got != want {
+			t.Fatalf("%s: want %q, got %q", layout, want, got)
+		}
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
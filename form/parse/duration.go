@@ -0,0 +1,158 @@
+package parse
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// unit describes a unit of time by its names, from shortest to longest.
+type unit struct {
+	Size  time.Duration
+	Names []string
+}
+
+var units = []unit{
+	{Size: time.Hour * 24 * 7, Names: []string{"w", "wk", "wks", "week", "weeks"}},
+	{Size: time.Hour * 24, Names: []string{"d", "day", "days"}},
+	{Size: time.Hour, Names: []string{"h", "hr", "hrs", "hour", "hours"}},
+	{Size: time.Minute, Names: []string{"m", "min", "mins", "minute", "minutes"}},
+	{Size: time.Second, Names: []string{"s", "sec", "secs", "second", "seconds"}},
+	{Size: time.Millisecond, Names: []string{"ms", "msec", "msecs", "millisecond", "milliseconds"}},
+}
+
+// Duration parses a length of time written by a person, such as "1h30m", "90 minutes",
+// "2 weeks 3 days", "1.5h" or "1 hour, 30 minutes". Months and years are rejected because their
+// length varies.
+func Duration(s string) (time.Duration, error) {
+	return DurationIn(s, 0)
+}
+
+// DurationIn parses like `Duration`, additionally reading a bare number in the given unit, such
+// that with a unit of one day "3" is three days.
+func DurationIn(s string, bare time.Duration) (time.Duration, error) {
+	var (
+		invalid = fmt.Errorf(`must be a duration such as "1h30m" or "2 days"`)
+		text    = strings.ToLower(strings.TrimSpace(s))
+		total   float64
+		sign    = 1.0
+		parsed  bool
+	)
+	if strings.HasPrefix(text, "-") {
+		sign, text = -1, text[1:]
+	}
+	if n, err := strconv.ParseFloat(text, 64); err == nil && bare > 0 {
+		return time.Duration(math.Round(sign * n * float64(bare))), nil
+	}
+	for {
+		text = strings.TrimLeftFunc(text, func(r rune) bool { return unicode.IsSpace(r) || r == ',' })
+		text = strings.TrimPrefix(text, "and ")
+		if text == "" {
+			break
+		}
+		end := strings.IndexFunc(text, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+		if end <= 0 {
+			return 0, invalid
+		}
+		n, err := strconv.ParseFloat(text[:end], 64)
+		if err != nil {
+			return 0, invalid
+		}
+		text = strings.TrimLeftFunc(text[end:], unicode.IsSpace)
+		end = strings.IndexFunc(text, func(r rune) bool { return !unicode.IsLetter(r) })
+		if end < 0 {
+			end = len(text)
+		}
+		size, ok := lookupUnit(text[:end])
+		if !ok {
+			return 0, invalid
+		}
+		text = text[end:]
+		total += n * float64(size)
+		parsed = true
+	}
+	if !parsed {
+		return 0, invalid
+	}
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("must be a shorter duration")
+	}
+	return time.Duration(math.Round(sign * total)), nil
+}
+
+func lookupUnit(name string) (time.Duration, bool) {
+	for _, u := range units {
+		for _, n := range u.Names {
+			if n == name {
+				return u.Size, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// FormatDuration formats a duration in the given units, largest first, such as "1h30m" for hours
+// and minutes. Any remainder is written as a fraction of the smallest unit. Long form spells the
+// units out, such as "1 hour 30 minutes".
+//
+// Units are weeks, days, hours, minutes, seconds and milliseconds; others are ignored. Units
+// default to hours and minutes.
+func FormatDuration(d time.Duration, sizes []time.Duration, long bool) string {
+	var known []time.Duration
+	for _, size := range sizes {
+		if unitName(size, "", false) != "" {
+			known = append(known, size)
+		}
+	}
+	if sizes = known; len(sizes) == 0 {
+		sizes = []time.Duration{time.Hour, time.Minute}
+	}
+	sort.Slice(sizes, func(ii, jj int) bool { return sizes[ii] > sizes[jj] })
+	var (
+		parts []string
+		sign  string
+	)
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	for ii, size := range sizes {
+		var n string
+		if ii == len(sizes)-1 {
+			n = strconv.FormatFloat(float64(d)/float64(size), 'f', -1, 64)
+		} else if d >= size {
+			n = strconv.FormatInt(int64(d/size), 10)
+			d %= size
+		} else {
+			continue
+		}
+		if n == "0" && len(parts) > 0 {
+			continue
+		}
+		parts = append(parts, n+unitName(size, n, long))
+	}
+	if long {
+		return sign + strings.Join(parts, " ")
+	}
+	return sign + strings.Join(parts, "")
+}
+
+// unitName names a unit of time, pluralizing the long form, or returns empty for an unknown unit.
+func unitName(size time.Duration, n string, long bool) string {
+	for _, u := range units {
+		if u.Size == size {
+			if !long {
+				return u.Names[0]
+			}
+			name := u.Names[len(u.Names)-2]
+			if n != "1" {
+				name = u.Names[len(u.Names)-1]
+			}
+			return " " + name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package parse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	for text, want := range map[string]time.Duration{
+		"1h30m":              90 * time.Minute,
+		"1.5h":               90 * time.Minute,
+		"90 minutes":         90 * time.Minute,
+		"1 hour, 30 minutes": 90 * time.Minute,
+		"1 day and 2 hours":  26 * time.Hour,
+		"2 weeks 3 days":     17 * 24 * time.Hour,
+		"2w3d":               17 * 24 * time.Hour,
+		"45s":                45 * time.Second,
+		"-15 mins":           -15 * time.Minute,
+		"1 Hour 500 ms":      time.Hour + 500*time.Millisecond,
+	} {
+		got, err := Duration(text)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if got != want {
+			t.Fatalf("%q: want %v, got %v", text, want, got)
+		}
+	}
+	for _, text := range []string{"", "3", "1 month", "h", "1h x", "1..5h"} {
+		if d, err := Duration(text); err == nil {
+			t.Fatalf("%q: want error, got %v", text, d)
+		}
+	}
+	if d, err := DurationIn("3", 24*time.Hour); err != nil || d != 72*time.Hour {
+		t.Fatalf("want 72h, got %v %v", d, err)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	day := 24 * time.Hour
+	for _, tt := range []struct {
+		D     time.Duration
+		Units []time.Duration
+		Long  bool
+		Want  string
+	}{
+		{D: 90 * time.Minute, Want: "1h30m"},
+		{D: 90 * time.Minute, Units: []time.Duration{time.Hour}, Want: "1.5h"},
+		{D: 90 * time.Minute, Long: true, Want: "1 hour 30 minutes"},
+		{D: 17 * day, Units: []time.Duration{day, 7 * day}, Want: "2w3d"},
+		{D: 2 * time.Hour, Want: "2h"},
+		{D: 0, Long: true, Want: "0 minutes"},
+		{D: -time.Minute, Want: "-1m"},
+	} {
+		got := FormatDuration(tt.D, tt.Units, tt.Long)
+		if got != tt.Want {
+			t.Fatalf("%v: want %q, got %q", tt.D, tt.Want, got)
+		}
+		if d, err := Duration(got); err != nil || d != tt.D {
+			t.Fatalf("%q: round trip want %v, got %v %v", got, tt.D, d, err)
+		}
+	}
+}
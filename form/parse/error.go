@@ -0,0 +1,27 @@
+package parse
+
+// Code identifies the kind of problem found while parsing, for callers that react to or localize
+// errors rather than display the message.
+type Code string
+
+const (
+	CodeSyntax   Code = "syntax"
+	CodeLength   Code = "length"
+	CodeScheme   Code = "scheme"
+	CodeHost     Code = "host"
+	CodeRegion   Code = "region"
+	CodeVersion  Code = "version"
+	CodeHostBits Code = "host-bits"
+	CodeChecksum Code = "checksum"
+	CodeBrand    Code = "brand"
+)
+
+// Error is a parse error identified by a code.
+type Error struct {
+	Code    Code
+	Message string
+}
+
+func (err Error) Error() string {
+	return err.Message
+}
@@ -0,0 +1,289 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// compact removes spaces and hyphens, which people use to group long identifiers.
+func compact(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '\u00a0' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+}
+
+// Brand is the issuing network of a payment card.
+type Brand string
+
+const (
+	Visa       Brand = "Visa"
+	Mastercard Brand = "Mastercard"
+	Amex       Brand = "American Express"
+	Discover   Brand = "Discover"
+	JCB        Brand = "JCB"
+	Diners     Brand = "Diners Club"
+	UnionPay   Brand = "UnionPay"
+)
+
+// brands maps the leading digits of card numbers to brands and their valid lengths.
+// Ranges are checked in order, so more specific prefixes come first.
+var brands = []struct {
+	Brand    Brand
+	From, To int
+	Digits   int
+	Lengths  []int
+}{
+	{Brand: Amex, From: 34, To: 34, Digits: 2, Lengths: []int{15}},
+	{Brand: Amex, From: 37, To: 37, Digits: 2, Lengths: []int{15}},
+	{Brand: Diners, From: 300, To: 305, Digits: 3, Lengths: []int{14, 16, 19}},
+	{Brand: Diners, From: 36, To: 36, Digits: 2, Lengths: []int{14, 16, 19}},
+	{Brand: Diners, From: 38, To: 39, Digits: 2, Lengths: []int{14, 16, 19}},
+	{Brand: JCB, From: 3528, To: 3589, Digits: 4, Lengths: []int{16, 17, 18, 19}},
+	{Brand: Visa, From: 4, To: 4, Digits: 1, Lengths: []int{13, 16, 19}},
+	{Brand: Mastercard, From: 51, To: 55, Digits: 2, Lengths: []int{16}},
+	{Brand: Mastercard, From: 2221, To: 2720, Digits: 4, Lengths: []int{16}},
+	{Brand: Discover, From: 6011, To: 6011, Digits: 4, Lengths: []int{16, 17, 18, 19}},
+	{Brand: Discover, From: 644, To: 649, Digits: 3, Lengths: []int{16, 17, 18, 19}},
+	{Brand: Discover, From: 65, To: 65, Digits: 2, Lengths: []int{16, 17, 18, 19}},
+	{Brand: UnionPay, From: 62, To: 62, Digits: 2, Lengths: []int{16, 17, 18, 19}},
+}
+
+// CardBrand detects the brand of a card number from its leading digits, or returns "" if unknown.
+func CardBrand(number string) Brand {
+	brand, _ := cardBrand(number)
+	return brand
+}
+
+func cardBrand(number string) (Brand, []int) {
+	for _, b := range brands {
+		if len(number) < b.Digits {
+			continue
+		}
+		if n, err := strconv.Atoi(number[:b.Digits]); err == nil && n >= b.From && n <= b.To {
+			return b.Brand, b.Lengths
+		}
+	}
+	return "", []int{12, 13, 14, 15, 16, 17, 18, 19}
+}
+
+// Card parses a payment card number, such as "4111 1111 1111 1111", into its digits.
+// The length must suit the brand and the Luhn check digit must match.
+func Card(s string) (string, error) {
+	number := compact(s)
+	if number == "" || !isDigits(number) {
+		return "", Error{Code: CodeSyntax, Message: "must be a card number of digits"}
+	}
+	brand, lengths := cardBrand(number)
+	valid := false
+	for _, n := range lengths {
+		valid = valid || len(number) == n
+	}
+	if !valid {
+		message := fmt.Sprintf("must be %s digits", list(lengths))
+		if brand != "" {
+			message += " for " + string(brand)
+		}
+		return "", Error{Code: CodeLength, Message: message}
+	}
+	if !luhn(number) {
+		return "", Error{Code: CodeChecksum, Message: "must be a valid card number, check for a mistyped digit"}
+	}
+	return number, nil
+}
+
+// FormatCard groups the digits of a card number as printed on the card.
+func FormatCard(number string) string {
+	groups := []int{4, 4, 4, 4, 4}
+	if CardBrand(number) == Amex {
+		groups = []int{4, 6, 5}
+	}
+	return group(number, groups)
+}
+
+// luhn reports whether the final digit of number is its Luhn check digit.
+func luhn(number string) bool {
+	sum := 0
+	for ii := range number {
+		d := int(number[len(number)-1-ii] - '0')
+		if ii%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// ibanLengths maps IBAN country codes to the length of their IBANs.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26,
+	"IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+	"LV": 21, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18,
+	"NO": 15, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24,
+	"SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28, "TL": 23, "TN": 24,
+	"TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+// IBAN parses an international bank account number, such as "GB82 WEST 1234 5698 7654 32", into
+// its compact uppercase form. The length must suit the country and the mod-97 check digits must
+// match.
+func IBAN(s string) (string, error) {
+	iban := strings.ToUpper(compact(s))
+	for ii, r := range iban {
+		letter, digit := r >= 'A' && r <= 'Z', r >= '0' && r <= '9'
+		if ii < 2 && !letter || ii >= 2 && ii < 4 && !digit || !letter && !digit {
+			return "", Error{Code: CodeSyntax, Message: "must be an IBAN such as GB82 WEST 1234 5698 7654 32"}
+		}
+	}
+	if len(iban) < 4 {
+		return "", Error{Code: CodeSyntax, Message: "must be an IBAN such as GB82 WEST 1234 5698 7654 32"}
+	}
+	country := iban[:2]
+	n, ok := ibanLengths[country]
+	if !ok {
+		return "", Error{Code: CodeRegion, Message: fmt.Sprintf("must start with a country that uses IBANs, not %s", country)}
+	}
+	if len(iban) != n {
+		return "", Error{Code: CodeLength, Message: fmt.Sprintf("must be %d characters for %s", n, country)}
+	}
+	if mod97(iban[4:]+iban[:4]) != 1 {
+		return "", Error{Code: CodeChecksum, Message: "must be a valid IBAN, check for a mistyped character"}
+	}
+	return iban, nil
+}
+
+// FormatIBAN groups an IBAN in fours, as printed.
+func FormatIBAN(iban string) string {
+	return group(iban, nil)
+}
+
+// mod97 computes the remainder of the number formed by replacing letters with 10 to 35.
+func mod97(s string) int {
+	rem := 0
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			rem = (rem*100 + int(r-'A'+10)) % 97
+		} else {
+			rem = (rem*10 + int(r-'0')) % 97
+		}
+	}
+	return rem
+}
+
+// ISBN parses an ISBN-10 or ISBN-13, such as "978-0-306-40615-7", into its digits.
+// An ISBN-10 may end in X, which checks as 10.
+func ISBN(s string) (string, error) {
+	isbn := strings.ToUpper(compact(s))
+	switch len(isbn) {
+	case 10:
+		if !isDigits(isbn[:9]) || !isDigits(isbn[9:]) && isbn[9] != 'X' {
+			return "", Error{Code: CodeSyntax, Message: "must be an ISBN of digits"}
+		}
+		sum := 0
+		for ii, r := range isbn {
+			d := int(r - '0')
+			if r == 'X' {
+				d = 10
+			}
+			sum += (10 - ii) * d
+		}
+		if sum%11 != 0 {
+			return "", Error{Code: CodeChecksum, Message: "must be a valid ISBN, check for a mistyped digit"}
+		}
+	case 13:
+		if !isDigits(isbn) {
+			return "", Error{Code: CodeSyntax, Message: "must be an ISBN of digits"}
+		}
+		if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+			return "", Error{Code: CodeSyntax, Message: "must start with 978 or 979"}
+		}
+		if isbn13Check(isbn[:12]) != isbn[12] {
+			return "", Error{Code: CodeChecksum, Message: "must be a valid ISBN, check for a mistyped digit"}
+		}
+	default:
+		return "", Error{Code: CodeLength, Message: "must be 10 or 13 digits"}
+	}
+	return isbn, nil
+}
+
+// ISBN13 converts a valid ISBN-10 to its ISBN-13, leaving ISBN-13s as is.
+func ISBN13(isbn string) string {
+	if len(isbn) != 10 {
+		return isbn
+	}
+	isbn = "978" + isbn[:9]
+	return isbn + string(isbn13Check(isbn))
+}
+
+// isbn13Check computes the check digit of the first 12 digits of an ISBN-13.
+func isbn13Check(digits string) byte {
+	sum := 0
+	for ii, r := range digits {
+		d := int(r - '0')
+		if ii%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// UUID parses a UUID, such as "{123E4567-E89B-12D3-A456-426614174000}", into its canonical
+// lowercase hyphenated form. Braces, a "urn:uuid:" prefix and missing hyphens are accepted.
+func UUID(s string) (string, error) {
+	text := strings.ToLower(strings.TrimSpace(s))
+	text = strings.TrimPrefix(text, "urn:uuid:")
+	if strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}") {
+		text = text[1 : len(text)-1]
+	}
+	hex := compact(text)
+	for _, r := range hex {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return "", Error{Code: CodeSyntax, Message: "must be a UUID of hexadecimal digits"}
+		}
+	}
+	if len(hex) != 32 {
+		return "", Error{Code: CodeLength, Message: "must be 32 hexadecimal digits"}
+	}
+	return hex[:8] + "-" + hex[8:12] + "-" + hex[12:16] + "-" + hex[16:20] + "-" + hex[20:], nil
+}
+
+// group splits s into space separated groups of the given sizes, then fours.
+func group(s string, sizes []int) string {
+	var b strings.Builder
+	for ii := 0; len(s) > 0; ii++ {
+		n := 4
+		if ii < len(sizes) {
+			n = sizes[ii]
+		}
+		if n > len(s) {
+			n = len(s)
+		}
+		if ii > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(s[:n])
+		s = s[n:]
+	}
+	return b.String()
+}
+
+// list joins numbers as a phrase, such as "13, 16 or 19".
+func list(ns []int) string {
+	var text []string
+	for _, n := range ns {
+		text = append(text, strconv.Itoa(n))
+	}
+	if len(text) == 1 {
+		return text[0]
+	}
+	return strings.Join(text[:len(text)-1], ", ") + " or " + text[len(text)-1]
+}
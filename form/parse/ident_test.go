@@ -0,0 +1,130 @@
+package parse
+
+import "testing"
+
+func TestCard(t *testing.T) {
+	for _, tt := range []struct {
+		text, want, formatted string
+		brand                 Brand
+	}{
+		{"4111 1111 1111 1111", "4111111111111111", "4111 1111 1111 1111", Visa},
+		{"5555-5555-5555-4444", "5555555555554444", "5555 5555 5555 4444", Mastercard},
+		{"2223003122003222", "2223003122003222", "2223 0031 2200 3222", Mastercard},
+		{"378282246310005", "378282246310005", "3782 822463 10005", Amex},
+		{"6011111111111117", "6011111111111117", "6011 1111 1111 1117", Discover},
+		{"3530111333300000", "3530111333300000", "3530 1113 3330 0000", JCB},
+	} {
+		got, err := Card(tt.text)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.text, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%q: want %q, got %q", tt.text, tt.want, got)
+		}
+		if brand := CardBrand(got); brand != tt.brand {
+			t.Fatalf("%q: want %s, got %s", tt.text, tt.brand, brand)
+		}
+		if formatted := FormatCard(got); formatted != tt.formatted {
+			t.Fatalf("%q: want %q, got %q", tt.text, tt.formatted, formatted)
+		}
+	}
+	for text, want := range map[string]Error{
+		"4111 1111 1111 1112": {Code: CodeChecksum, Message: "must be a valid card number, check for a mistyped digit"},
+		"4111 1111 1111":      {Code: CodeLength, Message: "must be 13, 16 or 19 digits for Visa"},
+		"3782 822463 1000":    {Code: CodeLength, Message: "must be 15 digits for American Express"},
+		"9999 99":             {Code: CodeLength, Message: "must be 12, 13, 14, 15, 16, 17, 18 or 19 digits"},
+		"4111 x111":           {Code: CodeSyntax, Message: "must be a card number of digits"},
+		"":                    {Code: CodeSyntax, Message: "must be a card number of digits"},
+	} {
+		if _, err := Card(text); err != want {
+			t.Fatalf("%q: want %#v, got %#v", text, want, err)
+		}
+	}
+}
+
+func TestIBAN(t *testing.T) {
+	for text, want := range map[string]string{
+		"GB82 WEST 1234 5698 7654 32": "GB82WEST12345698765432",
+		"de89370400440532013000":      "DE89370400440532013000",
+		"NO93 8601 1117 947":          "NO9386011117947",
+	} {
+		got, err := IBAN(text)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if got != want {
+			t.Fatalf("%q: want %q, got %q", text, want, got)
+		}
+	}
+	if got := FormatIBAN("GB82WEST12345698765432"); got != "GB82 WEST 1234 5698 7654 32" {
+		t.Fatalf("unexpected format: %q", got)
+	}
+	for text, want := range map[string]Code{
+		"GB82 WEST 1234 5698 7654 33": CodeChecksum,
+		"GB82 WEST 1234 5698 7654":    CodeLength,
+		"ZZ82 WEST 1234 5698 7654 32": CodeRegion,
+		"GB8X WEST":                   CodeSyntax,
+		"GB82 WEST_1234":              CodeSyntax,
+		"GB":                          CodeSyntax,
+	} {
+		if _, err := IBAN(text); code(err) != want {
+			t.Fatalf("%q: want %q, got %v", text, want, err)
+		}
+	}
+}
+
+func TestISBN(t *testing.T) {
+	for text, want := range map[string]string{
+		"0-306-40615-2":     "0306406152",
+		"978-0-306-40615-7": "9780306406157",
+		"0 8044 2957 x":     "080442957X",
+	} {
+		got, err := ISBN(text)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if got != want {
+			t.Fatalf("%q: want %q, got %q", text, want, got)
+		}
+	}
+	if got := ISBN13("0306406152"); got != "9780306406157" {
+		t.Fatalf("want %q, got %q", "9780306406157", got)
+	}
+	for text, want := range map[string]Code{
+		"0-306-40615-3":     CodeChecksum,
+		"978-0-306-40615-8": CodeChecksum,
+		"0-306-40615":       CodeLength,
+		"0-306-4061X-2":     CodeSyntax,
+		"123-0-306-40615-7": CodeSyntax,
+	} {
+		if _, err := ISBN(text); code(err) != want {
+			t.Fatalf("%q: want %q, got %v", text, want, err)
+		}
+	}
+}
+
+func TestUUID(t *testing.T) {
+	want := "123e4567-e89b-12d3-a456-426614174000"
+	for _, text := range []string{
+		"123e4567-e89b-12d3-a456-426614174000",
+		"{123E4567-E89B-12D3-A456-426614174000}",
+		"urn:uuid:123e4567-e89b-12d3-a456-426614174000",
+		"123e4567e89b12d3a456426614174000",
+	} {
+		got, err := UUID(text)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if got != want {
+			t.Fatalf("%q: want %q, got %q", text, want, got)
+		}
+	}
+	for text, want := range map[string]Code{
+		"123e4567-e89b-12d3-a456-42661417400":  CodeLength,
+		"123e4567-e89b-12d3-a456-42661417400g": CodeSyntax,
+	} {
+		if _, err := UUID(text); code(err) != want {
+			t.Fatalf("%q: want %q, got %v", text, want, err)
+		}
+	}
+}
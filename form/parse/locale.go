@@ -0,0 +1,296 @@
+package parse
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Locale describes how numbers are written in a region.
+type Locale struct {
+	// Tag is the BCP 47 language tag, such as "en" or "de-CH".
+	Tag string `json:"tag"`
+	// Decimal separates the integer and fractional parts.
+	Decimal string `json:"decimal"`
+	// Group separates groups of thousands.
+	Group string `json:"group"`
+	// Minus prefixes negative numbers.
+	Minus string `json:"minus"`
+	// Percent suffixes percentages, including any leading space.
+	Percent string `json:"percent"`
+	// Permille suffixes per mille amounts, including any leading space.
+	Permille string `json:"permille"`
+	// Currency places the currency symbol "¤" relative to the number "#", such as "#\u00a0¤".
+	Currency string `json:"currency"`
+}
+
+// DefaultLocale is the locale used when none is specified.
+var DefaultLocale = Locale{
+	Tag:      "en",
+	Decimal:  ".",
+	Group:    ",",
+	Minus:    "-",
+	Percent:  "%",
+	Permille: "‰",
+	Currency: "¤#",
+}
+
+//go:embed locales.json
+var localeData []byte
+
+var (
+	locales     map[string]Locale
+	localesOnce sync.Once
+)
+
+// LookupLocale finds a locale in the embedded table by language tag, falling back from a regional
+// tag such as "de-AT" to the language "de".
+func LookupLocale(tag string) (Locale, bool) {
+	localesOnce.Do(func() {
+		var list []Locale
+		if err := json.Unmarshal(localeData, &list); err != nil {
+			panic(fmt.Errorf("decoding embedded locales: %w", err))
+		}
+		locales = make(map[string]Locale, len(list))
+		for _, l := range list {
+			locales[strings.ToLower(l.Tag)] = l
+		}
+	})
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	for tag != "" {
+		if l, ok := locales[tag]; ok {
+			return l, true
+		}
+		dash := strings.LastIndexByte(tag, '-')
+		if dash < 0 {
+			break
+		}
+		tag = tag[:dash]
+	}
+	return Locale{}, false
+}
+
+// ParseFloat parses a number as written in the locale.
+//
+// Grouping separators are optional but must delimit groups of three digits. A trailing percent or
+// permille sign scales the number, such that "12.5%" is 0.125.
+func (l Locale) ParseFloat(s string) (float64, error) {
+	text, scale, err := l.normalize(s)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("must be a valid number")
+	}
+	return n / scale, nil
+}
+
+// ParseInt parses an integer as written in the locale.
+func (l Locale) ParseInt(s string) (int, error) {
+	text, scale, err := l.normalize(s)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil || scale != 1 {
+		return 0, fmt.Errorf("must be a valid number")
+	}
+	return n, nil
+}
+
+// FormatFloat formats a number as written in the locale.
+// The verb and precision are interpreted by `strconv.FormatFloat`.
+func (l Locale) FormatFloat(n float64, verb byte, prec int, group bool) string {
+	return l.localize(strconv.FormatFloat(n, verb, prec, 64), group)
+}
+
+// ParseDecimal parses an exact decimal number as written in the locale.
+func (l Locale) ParseDecimal(s string) (*big.Rat, error) {
+	text, scale, err := l.normalize(s)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("must be a valid number")
+	}
+	return r.Quo(r, new(big.Rat).SetFloat64(scale)), nil
+}
+
+// FormatDecimal formats an exact decimal number as written in the locale, rounded to the given
+// number of decimal places. Negative places formats as many places as the number needs.
+func (l Locale) FormatDecimal(r *big.Rat, places int, group bool) string {
+	if places < 0 {
+		places = Places(r)
+	}
+	return l.localize(r.FloatString(places), group)
+}
+
+// Places counts the decimal places needed to represent r exactly.
+// Recurring decimals, such as 1/3, are limited to 32 places.
+func Places(r *big.Rat) int {
+	const limit = 32
+	var (
+		d      = new(big.Int).Set(r.Denom())
+		one    = big.NewInt(1)
+		two    = big.NewInt(2)
+		five   = big.NewInt(5)
+		mod    = new(big.Int)
+		places = 0
+	)
+	for d.Cmp(one) != 0 {
+		switch {
+		case mod.Mod(d, two).Sign() == 0 && mod.Mod(d, five).Sign() == 0:
+			d.Quo(d, big.NewInt(10))
+		case mod.Mod(d, two).Sign() == 0:
+			d.Quo(d, two)
+		case mod.Mod(d, five).Sign() == 0:
+			d.Quo(d, five)
+		default:
+			return limit
+		}
+		if places++; places > limit {
+			return limit
+		}
+	}
+	return places
+}
+
+// FormatInt formats an integer as written in the locale.
+func (l Locale) FormatInt(n int, group bool) string {
+	return l.localize(strconv.Itoa(n), group)
+}
+
+// localize rewrites a number formatted by strconv into the locale.
+func (l Locale) localize(text string, group bool) string {
+	var sign string
+	if strings.HasPrefix(text, "-") {
+		sign, text = l.Minus, text[1:]
+	}
+	var exponent string
+	if e := strings.IndexAny(text, "eE"); e >= 0 {
+		text, exponent = text[:e], text[e:]
+	}
+	integer, fraction := text, ""
+	if dot := strings.IndexByte(text, '.'); dot >= 0 {
+		integer, fraction = text[:dot], l.Decimal+text[dot+1:]
+	}
+	if group && exponent == "" && len(integer) > 3 {
+		var b strings.Builder
+		for ii, digit := range integer {
+			if ii > 0 && (len(integer)-ii)%3 == 0 {
+				b.WriteString(l.Group)
+			}
+			b.WriteRune(digit)
+		}
+		integer = b.String()
+	}
+	return sign + integer + fraction + exponent
+}
+
+// maxExponent bounds the exponent of a number, well beyond the range of float64.
+const maxExponent = 1000
+
+// normalize rewrites a number written in the locale into the syntax accepted by strconv, along
+// with the divisor implied by a percent or permille sign.
+func (l Locale) normalize(s string) (text string, scale float64, err error) {
+	invalid := fmt.Errorf("must be a valid number")
+	s = strings.TrimFunc(s, unicode.IsSpace)
+	scale = 1
+	for _, suffix := range []struct {
+		Signs []string
+		Scale float64
+	}{
+		{Signs: []string{l.Percent, "%"}, Scale: 100},
+		{Signs: []string{l.Permille, "‰"}, Scale: 1000},
+	} {
+		for _, sign := range suffix.Signs {
+			sign = strings.TrimFunc(sign, unicode.IsSpace)
+			if sign != "" && strings.HasSuffix(s, sign) {
+				s, scale = strings.TrimFunc(strings.TrimSuffix(s, sign), unicode.IsSpace), suffix.Scale
+				break
+			}
+		}
+		if scale != 1 {
+			break
+		}
+	}
+	var sign string
+	for _, minus := range []string{l.Minus, "-", "−"} {
+		if minus != "" && strings.HasPrefix(s, minus) {
+			sign, s = "-", s[len(minus):]
+			break
+		}
+	}
+	if sign == "" {
+		s = strings.TrimPrefix(s, "+")
+	}
+	var exponent string
+	if e := strings.IndexAny(s, "eE"); e >= 0 {
+		s, exponent = s[:e], s[e:]
+		// Bound the exponent, such that exact decimals stay cheap to compute.
+		if n, err := strconv.Atoi(exponent[1:]); err != nil || n < -maxExponent || n > maxExponent {
+			return "", 0, invalid
+		}
+	}
+	integer, fraction := s, ""
+	if l.Decimal != "" {
+		if dot := strings.Index(s, l.Decimal); dot >= 0 {
+			integer, fraction = s[:dot], s[dot+len(l.Decimal):]
+		}
+	}
+	integer, ok := l.ungroup(integer)
+	if !ok || !isDigits(fraction) || (integer == "" && fraction == "") {
+		return "", 0, invalid
+	}
+	if integer == "" {
+		integer = "0"
+	}
+	text = sign + integer
+	if fraction != "" {
+		text += "." + fraction
+	}
+	return text + exponent, scale, nil
+}
+
+// ungroup removes grouping separators from the integer part of a number, reporting whether they
+// delimit groups of three digits.
+func (l Locale) ungroup(integer string) (string, bool) {
+	separators := []string{l.Group}
+	if strings.TrimFunc(l.Group, unicode.IsSpace) == "" {
+		// Space separators are hard to type, so accept any space.
+		separators = []string{l.Group, " ", "\u00a0", "\u202f"}
+	}
+	var groups []string
+	for _, sep := range separators {
+		if sep != "" && strings.Contains(integer, sep) {
+			groups = strings.Split(integer, sep)
+			break
+		}
+	}
+	if groups == nil {
+		return integer, isDigits(integer)
+	}
+	for ii, group := range groups {
+		if !isDigits(group) || group == "" || len(group) > 3 || (ii > 0 && len(group) != 3) {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// isDigits reports whether s contains only ascii digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
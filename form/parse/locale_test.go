@@ -0,0 +1,108 @@
+package parse
+
+import (
+	"math/big"
+	"testing"
+)
+
+func mustLocale(t *testing.T, tag string) Locale {
+	t.Helper()
+	l, ok := LookupLocale(tag)
+	if !ok {
+		t.Fatalf("locale %q not found", tag)
+	}
+	return l
+}
+
+func TestLookupLocale(t *testing.T) {
+	if l := mustLocale(t, "de_AT"); l.Tag != "de-AT" {
+		t.Fatalf("want de-AT, got %q", l.Tag)
+	}
+	if l := mustLocale(t, "de-LU"); l.Tag != "de" {
+		t.Fatalf("want fallback to de, got %q", l.Tag)
+	}
+	if _, ok := LookupLocale("xx"); ok {
+		t.Fatalf("want unknown locale")
+	}
+}
+
+func TestLocaleParseFloat(t *testing.T) {
+	for _, tt := range []struct {
+		Tag  string
+		Text string
+		Want float64
+		Err  bool
+	}{
+		{Tag: "en", Text: "1,234.56", Want: 1234.56},
+		{Tag: "en", Text: "1234.56", Want: 1234.56},
+		{Tag: "en", Text: "-0.5", Want: -0.5},
+		{Tag: "en", Text: ".5", Want: 0.5},
+		{Tag: "en", Text: "12.5%", Want: 0.125},
+		{Tag: "en", Text: "5‰", Want: 0.005},
+		{Tag: "en", Text: "6.02e23", Want: 6.02e23},
+		{Tag: "en", Text: "1e-999999", Err: true},
+		{Tag: "en", Text: "1e+999999", Err: true},
+		{Tag: "en", Text: "1,5", Err: true},
+		{Tag: "en", Text: "1,2345", Err: true},
+		{Tag: "en", Text: "1.2.3", Err: true},
+		{Tag: "en", Text: "", Err: true},
+		{Tag: "de", Text: "1.234,56", Want: 1234.56},
+		{Tag: "de", Text: "12,5 %", Want: 0.125},
+		{Tag: "de", Text: "1,234.56", Err: true},
+		{Tag: "fr", Text: "1 234,56", Want: 1234.56},
+		{Tag: "fr", Text: "1\u202f234,56", Want: 1234.56},
+		{Tag: "sv", Text: "−1 234,5", Want: -1234.5},
+		{Tag: "de-CH", Text: "1’234.5", Want: 1234.5},
+	} {
+		n, err := mustLocale(t, tt.Tag).ParseFloat(tt.Text)
+		if tt.Err {
+			if err == nil {
+				t.Fatalf("%s %q: want error, got %v", tt.Tag, tt.Text, n)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s %q: unexpected error: %v", tt.Tag, tt.Text, err)
+		}
+		if n != tt.Want {
+			t.Fatalf("%s %q: want %v, got %v", tt.Tag, tt.Text, tt.Want, n)
+		}
+	}
+}
+
+func TestLocaleFormat(t *testing.T) {
+	for _, tt := range []struct {
+		Tag  string
+		N    float64
+		Want string
+	}{
+		{Tag: "en", N: 1234567.891, Want: "1,234,567.89"},
+		{Tag: "de", N: -1234.5, Want: "-1.234,50"},
+		{Tag: "fr", N: 1234.5, Want: "1\u202f234,50"},
+		{Tag: "sv", N: -12, Want: "−12,00"},
+	} {
+		if got := mustLocale(t, tt.Tag).FormatFloat(tt.N, 'f', 2, true); got != tt.Want {
+			t.Fatalf("%s %v: want %q, got %q", tt.Tag, tt.N, tt.Want, got)
+		}
+	}
+	if got := mustLocale(t, "de").FormatInt(-1234, true); got != "-1.234" {
+		t.Fatalf("want %q, got %q", "-1.234", got)
+	}
+	if n, err := mustLocale(t, "de").ParseInt("1.234"); err != nil || n != 1234 {
+		t.Fatalf("want 1234, got %v %v", n, err)
+	}
+	if _, err := mustLocale(t, "de").ParseInt("1,5"); err == nil {
+		t.Fatalf("want error for fractional integer")
+	}
+}
+
+func TestPlacesLarge(t *testing.T) {
+	r, _ := new(big.Rat).SetString("1e-1000")
+	if got := Places(r); got != 32 {
+		t.Fatalf("want places limited to 32, got %d", got)
+	}
+	r.SetFrac64(1, 8)
+	if got := Places(r); got != 3 {
+		t.Fatalf("want 3, got %d", got)
+	}
+}
@@ -0,0 +1,221 @@
+package parse
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// Email parses an RFC 5322 addr-spec, such as "jack@example.com" or "\"jack m\"@[192.0.2.1]".
+// Surrounding space is trimmed and the domain, which is case insensitive, is lowercased.
+func Email(s string) (string, error) {
+	var (
+		text    = strings.TrimSpace(s)
+		invalid = Error{Code: CodeSyntax, Message: "must be an email address such as name@example.com"}
+	)
+	at := strings.LastIndexByte(text, '@')
+	if at < 0 {
+		return "", invalid
+	}
+	local, domain := text[:at], text[at+1:]
+	if !dotAtom(local) && !quoted(local) {
+		return "", invalid
+	}
+	if !dotAtom(domain) && !domainLiteral(domain) {
+		return "", invalid
+	}
+	// Practical limits from RFC 5321.
+	if len(local) > 64 || len(text) > 254 {
+		return "", Error{Code: CodeLength, Message: "must be a shorter email address"}
+	}
+	return local + "@" + strings.ToLower(domain), nil
+}
+
+// dotAtom reports whether s is a sequence of atoms separated by single dots.
+func dotAtom(s string) bool {
+	for _, atom := range strings.Split(s, ".") {
+		if atom == "" {
+			return false
+		}
+		for _, r := range atom {
+			if !atext(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func atext(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r) || r > 127
+}
+
+// quoted reports whether s is a quoted string, in which any printable character may be escaped.
+func quoted(s string) bool {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return false
+	}
+	for ii := 1; ii < len(s)-1; ii++ {
+		switch c := s[ii]; {
+		case c == '\\':
+			ii++
+			if ii >= len(s)-1 {
+				return false
+			}
+		case c == '"' || c < ' ' && c != '\t':
+			return false
+		}
+	}
+	return true
+}
+
+// domainLiteral reports whether s is a bracketed literal, such as "[192.0.2.1]".
+func domainLiteral(s string) bool {
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return false
+	}
+	return !strings.ContainsAny(s[1:len(s)-1], "[]\\ ")
+}
+
+// URL parses an absolute URL with a host, such as "https://example.com/path".
+// If schemes are given, the scheme must be one of them.
+func URL(s string, schemes ...string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || u.Scheme == "" {
+		return nil, Error{Code: CodeSyntax, Message: "must be a link such as https://example.com"}
+	}
+	if len(schemes) > 0 {
+		allowed := false
+		for _, scheme := range schemes {
+			allowed = allowed || strings.EqualFold(u.Scheme, scheme)
+		}
+		if !allowed {
+			return nil, Error{Code: CodeScheme, Message: fmt.Sprintf("must start with %s://", strings.Join(schemes, ":// or "))}
+		}
+	}
+	if u.Opaque == "" && u.Hostname() == "" {
+		return nil, Error{Code: CodeHost, Message: "must include a host such as example.com"}
+	}
+	return u, nil
+}
+
+// region describes the telephone numbering of a region.
+type region struct {
+	// Code is the country calling code.
+	Code string
+	// Trunk is the prefix dialled before national numbers, removed in international form.
+	Trunk string
+}
+
+// regions maps ISO 3166 region codes to their telephone numbering.
+var regions = map[string]region{
+	"AT": {Code: "43", Trunk: "0"},
+	"AU": {Code: "61", Trunk: "0"},
+	"BE": {Code: "32", Trunk: "0"},
+	"BR": {Code: "55", Trunk: "0"},
+	"CA": {Code: "1", Trunk: "1"},
+	"CH": {Code: "41", Trunk: "0"},
+	"CN": {Code: "86", Trunk: "0"},
+	"DE": {Code: "49", Trunk: "0"},
+	"DK": {Code: "45"},
+	"ES": {Code: "34"},
+	"FI": {Code: "358", Trunk: "0"},
+	"FR": {Code: "33", Trunk: "0"},
+	"GB": {Code: "44", Trunk: "0"},
+	"HK": {Code: "852"},
+	"IE": {Code: "353", Trunk: "0"},
+	"IN": {Code: "91", Trunk: "0"},
+	"IT": {Code: "39"},
+	"JP": {Code: "81", Trunk: "0"},
+	"MX": {Code: "52"},
+	"NL": {Code: "31", Trunk: "0"},
+	"NO": {Code: "47"},
+	"NZ": {Code: "64", Trunk: "0"},
+	"SE": {Code: "46", Trunk: "0"},
+	"SG": {Code: "65"},
+	"US": {Code: "1", Trunk: "1"},
+	"ZA": {Code: "27", Trunk: "0"},
+}
+
+// Phone parses a telephone number into E.164 form, such as "+61412345678".
+//
+// International numbers start with "+" or "00". Other numbers are national numbers of the default
+// region, given as an ISO 3166 code such as "AU". Spaces, dots, hyphens and parentheses are
+// ignored.
+func Phone(s, defaultRegion string) (string, error) {
+	var digits strings.Builder
+	text := strings.TrimSpace(s)
+	international := strings.HasPrefix(text, "+")
+	for ii, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && ii == 0:
+		case strings.ContainsRune(" .-()/", r):
+		default:
+			return "", Error{Code: CodeSyntax, Message: "must be a phone number such as +61 412 345 678"}
+		}
+	}
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		international, number = true, number[2:]
+	}
+	if !international {
+		r, ok := regions[strings.ToUpper(defaultRegion)]
+		if !ok {
+			return "", Error{Code: CodeRegion, Message: "must start with + and the country code"}
+		}
+		if r.Trunk != "" && strings.HasPrefix(number, r.Trunk) {
+			number = number[len(r.Trunk):]
+		}
+		number = r.Code + number
+	}
+	if len(number) < 8 || len(number) > 15 {
+		return "", Error{Code: CodeLength, Message: "must be a phone number of 8 to 15 digits"}
+	}
+	if number[0] == '0' {
+		return "", Error{Code: CodeRegion, Message: "must start with a country code"}
+	}
+	return "+" + number, nil
+}
+
+// IP parses an IPv4 or IPv6 address. Version restricts the address to IPv4 or IPv6 if 4 or 6.
+func IP(s string, version int) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil {
+		return netip.Addr{}, Error{Code: CodeSyntax, Message: "must be an IP address such as 192.0.2.1"}
+	}
+	if err := checkVersion(addr, version); err != nil {
+		return netip.Addr{}, err
+	}
+	return addr, nil
+}
+
+// CIDR parses an IP prefix, such as "192.0.2.0/24". Version restricts the prefix to IPv4 or IPv6
+// if 4 or 6. Strict rejects prefixes with bits set after the prefix length, such as
+// "192.0.2.1/24".
+func CIDR(s string, version int, strict bool) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(s))
+	if err != nil {
+		return netip.Prefix{}, Error{Code: CodeSyntax, Message: "must be a network such as 192.0.2.0/24"}
+	}
+	if err := checkVersion(prefix.Addr(), version); err != nil {
+		return netip.Prefix{}, err
+	}
+	if strict && prefix.Masked() != prefix {
+		return netip.Prefix{}, Error{Code: CodeHostBits, Message: fmt.Sprintf("must not have host bits set, such as %s", prefix.Masked())}
+	}
+	return prefix, nil
+}
+
+func checkVersion(addr netip.Addr, version int) error {
+	switch {
+	case version == 4 && !addr.Is4():
+		return Error{Code: CodeVersion, Message: "must be an IPv4 address"}
+	case version == 6 && !addr.Is6():
+		return Error{Code: CodeVersion, Message: "must be an IPv6 address"}
+	}
+	return nil
+}
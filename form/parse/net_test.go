@@ -0,0 +1,174 @@
+package parse
+
+import (
+	"errors"
+	"testing"
+)
+
+func code(err error) Code {
+	var e Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
+
+func TestEmail(t *testing.T) {
+	for text, want := range map[string]string{
+		"jack@example.com":        "jack@example.com",
+		" Jack.M@Example.COM ":    "Jack.M@example.com",
+		"a+tag@sub.example.org":   "a+tag@sub.example.org",
+		`"jack m"@example.com`:    `"jack m"@example.com`,
+		`"a\"b"@example.com`:      `"a\"b"@example.com`,
+		"user@localhost":          "user@localhost",
+		"user@[192.0.2.1]":        "user@[192.0.2.1]",
+		"o'brien@example.ie":      "o'brien@example.ie",
+		"weird!#$%&*@example.com": "weird!#$%&*@example.com",
+	} {
+		got, err := Email(text)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if got != want {
+			t.Fatalf("%q: want %q, got %q", text, want, got)
+		}
+	}
+	long := ""
+	for len(long) < 65 {
+		long += "a"
+	}
+	for text, want := range map[string]Code{
+		"":                    CodeSyntax,
+		"jack":                CodeSyntax,
+		"@example.com":        CodeSyntax,
+		"jack@":               CodeSyntax,
+		"jack..m@example.com": CodeSyntax,
+		".jack@example.com":   CodeSyntax,
+		"jack m@example.com":  CodeSyntax,
+		"jack@exa mple.com":   CodeSyntax,
+		`"unterminated@x.com`: CodeSyntax,
+		long + "@example.com": CodeLength,
+	} {
+		_, err := Email(text)
+		if got := code(err); got != want {
+			t.Fatalf("%q: want %q, got %q (%v)", text, want, got, err)
+		}
+	}
+}
+
+func TestURL(t *testing.T) {
+	u, err := URL(" https://example.com/a?b=c ", "http", "https")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := u.String(); got != "https://example.com/a?b=c" {
+		t.Fatalf("want %q, got %q", "https://example.com/a?b=c", got)
+	}
+	if _, err := URL("mailto:jack@example.com"); err != nil {
+		t.Fatalf("opaque: unexpected error: %v", err)
+	}
+	for _, tt := range []struct {
+		text    string
+		schemes []string
+		want    Code
+		message string
+	}{
+		{text: "example.com", want: CodeSyntax},
+		{text: "ftp://example.com", schemes: []string{"http", "https"}, want: CodeScheme, message: "must start with http:// or https://"},
+		{text: "https:///path", want: CodeHost},
+		{text: "https://exa mple.com", want: CodeSyntax},
+	} {
+		_, err := URL(tt.text, tt.schemes...)
+		if got := code(err); got != tt.want {
+			t.Fatalf("%q: want %q, got %q (%v)", tt.text, tt.want, got, err)
+		}
+		if tt.message != "" && err.Error() != tt.message {
+			t.Fatalf("%q: want %q, got %q", tt.text, tt.message, err)
+		}
+	}
+}
+
+func TestPhone(t *testing.T) {
+	for _, tt := range []struct {
+		text, region, want string
+	}{
+		{"0412 345 678", "AU", "+61412345678"},
+		{"(02) 9876-5432", "au", "+61298765432"},
+		{"+61 412 345 678", "", "+61412345678"},
+		{"0061 412 345 678", "", "+61412345678"},
+		{"(415) 555-0132", "US", "+14155550132"},
+		{"1-415-555-0132", "US", "+14155550132"},
+		{"020 7946 0958", "GB", "+442079460958"},
+		{"06 1234 5678", "IT", "+390612345678"},
+	} {
+		got, err := Phone(tt.text, tt.region)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.text, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%q: want %q, got %q", tt.text, tt.want, got)
+		}
+	}
+	for _, tt := range []struct {
+		text, region string
+		want         Code
+	}{
+		{"0412 345 678", "", CodeRegion},
+		{"0412 345 678", "XX", CodeRegion},
+		{"0412 ext 5", "AU", CodeSyntax},
+		{"+61 4", "", CodeLength},
+		{"+61 412 345 678 901 23", "", CodeLength},
+		{"+0412345678", "", CodeRegion},
+	} {
+		_, err := Phone(tt.text, tt.region)
+		if got := code(err); got != tt.want {
+			t.Fatalf("%q: want %q, got %q (%v)", tt.text, tt.want, got, err)
+		}
+	}
+}
+
+func TestIP(t *testing.T) {
+	for _, tt := range []struct {
+		text    string
+		version int
+		want    Code
+	}{
+		{"192.0.2.1", 0, ""},
+		{"2001:db8::1", 0, ""},
+		{"192.0.2.1", 4, ""},
+		{"2001:db8::1", 4, CodeVersion},
+		{"192.0.2.1", 6, CodeVersion},
+		{"192.0.2", 0, CodeSyntax},
+		{"192.0.2.256", 0, CodeSyntax},
+	} {
+		_, err := IP(tt.text, tt.version)
+		if got := code(err); got != tt.want {
+			t.Fatalf("%q: want %q, got %q (%v)", tt.text, tt.want, got, err)
+		}
+	}
+}
+
+func TestCIDR(t *testing.T) {
+	for _, tt := range []struct {
+		text    string
+		version int
+		strict  bool
+		want    Code
+	}{
+		{"192.0.2.0/24", 0, true, ""},
+		{"192.0.2.1/24", 0, false, ""},
+		{"192.0.2.1/24", 0, true, CodeHostBits},
+		{"2001:db8::/32", 4, false, CodeVersion},
+		{"192.0.2.0", 0, false, CodeSyntax},
+		{"192.0.2.0/33", 0, false, CodeSyntax},
+	} {
+		_, err := CIDR(tt.text, tt.version, tt.strict)
+		if got := code(err); got != tt.want {
+			t.Fatalf("%q: want %q, got %q (%v)", tt.text, tt.want, got, err)
+		}
+	}
+	_, err := CIDR("192.0.2.1/24", 0, true)
+	if want := "must not have host bits set, such as 192.0.2.0/24"; err.Error() != want {
+		t.Fatalf("want %q, got %q", want, err)
+	}
+}
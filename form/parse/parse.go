@@ -2,10 +2,12 @@
 package parse
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
+	"unsafe"
 )
 
 // Int parses an integer from digit characters.
@@ -31,8 +33,8 @@ func Uint(s string) (uint, error) {
 	n, err := strconv.Atoi(s)
 	if err != nil {
 		return 0, fmt.Errorf("must be a valid number")
-	} else if n < 1 {
-		return 0, fmt.Errorf("must be an amount greater than 0")
+	} else if n < 0 {
+		return 0, fmt.Errorf("must not be negative")
 	}
 	return uint(n), nil
 }
@@ -42,6 +44,8 @@ func Day(s string) (time.Duration, error) {
 	n, err := Uint(s)
 	if err != nil {
 		return time.Duration(0), err
+	} else if n < 1 {
+		return time.Duration(0), fmt.Errorf("must be an amount greater than 0")
 	}
 	return time.Hour * 24 * time.Duration(n), nil
 }
@@ -56,26 +60,57 @@ func FieldRequired(s string) (string, error) {
 
 // FormatTime formats a time object into a string.
 func FormatTime(t time.Time) string {
-	return fmt.Sprintf("%d/%d/%d", t.Day(), t.Month(), t.Year())
+	return FormatDate(t, DMY)
+}
+
+// Date parses a time object from a textual dd/mm/yyyy format, rejecting impossible dates.
+func Date(s string) (time.Time, error) {
+	return DateParser{Layouts: []string{DMY}}.Parse(s)
+}
+
+// Integral is the set of integer types.
+type Integral interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
 }
 
-// Date parses a time object from a textual dd/mm/yyyy format.
-func Date(s string) (date time.Time, err error) {
-	parts := strings.Split(s, "/")
-	if len(parts) != 3 {
-		return date, fmt.Errorf("must be dd/mm/yyyy")
+// Integer parses an integer of any size from digit characters, rejecting numbers that don't fit.
+func Integer[T Integral](s string) (T, error) {
+	var (
+		zero   T
+		bits   = int(unsafe.Sizeof(zero)) * 8
+		signed = zero-1 < 0
+	)
+	if signed {
+		n, err := strconv.ParseInt(s, 10, bits)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("must be between %d and %d", int64(-1)<<(bits-1), int64(1)<<(bits-1)-1)
+		} else if err != nil {
+			return 0, fmt.Errorf("must be a valid number")
+		}
+		return T(n), nil
 	}
-	year, err := strconv.Atoi(parts[2])
-	if err != nil {
-		return date, fmt.Errorf("year not a number: %s", parts[2])
+	if strings.HasPrefix(s, "-") {
+		if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return 0, fmt.Errorf("must not be negative")
+		}
 	}
-	month, err := strconv.Atoi(parts[1])
-	if err != nil {
-		return date, fmt.Errorf("month not a number: %s", parts[2])
+	n, err := strconv.ParseUint(s, 10, bits)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("must be between 0 and %d", ^uint64(0)>>(64-bits))
+	} else if err != nil {
+		return 0, fmt.Errorf("must be a valid number")
 	}
-	day, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return date, fmt.Errorf("day not a number: %s", parts[2])
+	return T(n), nil
+}
+
+// Bool parses a boolean from words such as yes and no, true and false, or 1 and 0.
+// Empty text is false, as an unticked checkbox submits nothing.
+func Bool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "yes", "y", "true", "t", "1", "on":
+		return true, nil
+	case "no", "n", "false", "f", "0", "off", "":
+		return false, nil
 	}
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local), nil
+	return false, fmt.Errorf("must be yes or no")
 }
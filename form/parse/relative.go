@@ -0,0 +1,151 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Relative parses a date relative to now, for shorthand such as:
+//
+//	today, tomorrow, yesterday
+//	friday, next friday, last fri
+//	+3d, -2w, +1m, +1y
+//	in 3 days, 2 weeks ago
+//	next week, last month
+//	start of month, end of month, end of week, start of year
+//
+// A bare weekday is the next occurrence, including today. Adding months clamps to the end of
+// shorter months, such that a month after the 31st of January is the 28th of February. Weeks start
+// on Monday. The result is midnight in the location of now.
+func Relative(s string, now time.Time) (time.Time, error) {
+	var (
+		today   = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		fields  = strings.Fields(strings.ToLower(s))
+		invalid = fmt.Errorf(`must be a date such as "tomorrow", "next friday" or "+3d"`)
+	)
+	switch len(fields) {
+	case 1:
+		switch word := fields[0]; word {
+		case "today", "now":
+			return today, nil
+		case "tomorrow":
+			return today.AddDate(0, 0, 1), nil
+		case "yesterday":
+			return today.AddDate(0, 0, -1), nil
+		default:
+			if day, ok := weekday(word); ok {
+				return today.AddDate(0, 0, (int(day)-int(today.Weekday())+7)%7), nil
+			}
+			if len(word) > 2 && (word[0] == '+' || word[0] == '-') {
+				n, err := strconv.Atoi(word[1 : len(word)-1])
+				if err != nil {
+					return time.Time{}, invalid
+				}
+				if word[0] == '-' {
+					n = -n
+				}
+				if t, ok := add(today, n, word[len(word)-1:]); ok {
+					return t, nil
+				}
+			}
+		}
+	case 2:
+		direction := map[string]int{"next": 1, "last": -1}[fields[0]]
+		if direction == 0 {
+			break
+		}
+		if day, ok := weekday(fields[1]); ok {
+			offset := (int(day) - int(today.Weekday()) + 7) % 7
+			if direction > 0 && offset == 0 {
+				offset = 7
+			} else if direction < 0 {
+				offset = offset - 7
+			}
+			return today.AddDate(0, 0, offset), nil
+		}
+		if t, ok := add(today, direction, fields[1]); ok {
+			return t, nil
+		}
+	case 3:
+		n, err := strconv.Atoi(fields[0])
+		switch {
+		case fields[0] == "in":
+			n, err = strconv.Atoi(fields[1])
+			if t, ok := add(today, n, fields[2]); err == nil && ok {
+				return t, nil
+			}
+		case err == nil && fields[2] == "ago":
+			if t, ok := add(today, -n, fields[1]); ok {
+				return t, nil
+			}
+		case fields[1] == "of":
+			if t, ok := bound(today, fields[0], fields[2]); ok {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, invalid
+}
+
+// add moves t by n units, where unit is a day, week, month or year in short or long form.
+func add(t time.Time, n int, unit string) (time.Time, bool) {
+	switch strings.TrimSuffix(unit, "s") {
+	case "d", "day":
+		return t.AddDate(0, 0, n), true
+	case "w", "week":
+		return t.AddDate(0, 0, 7*n), true
+	case "m", "month":
+		return addMonths(t, n), true
+	case "y", "year":
+		return addMonths(t, 12*n), true
+	}
+	return t, false
+}
+
+// addMonths moves t by n months, clamping the day to the end of the target month.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	day := t.Day()
+	if days := daysIn(first.Month(), first.Year()); day > days {
+		day = days
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// bound finds the start or end of the week, month or year containing t.
+func bound(t time.Time, edge, period string) (time.Time, bool) {
+	var start, end time.Time
+	switch period {
+	case "week":
+		start = t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+		end = start.AddDate(0, 0, 6)
+	case "month":
+		start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		end = start.AddDate(0, 1, -1)
+	case "year":
+		start = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+		end = start.AddDate(1, 0, -1)
+	default:
+		return t, false
+	}
+	switch edge {
+	case "start", "beginning":
+		return start, true
+	case "end":
+		return end, true
+	}
+	return t, false
+}
+
+// weekday parses the name of a day of the week, in full or abbreviated to three letters.
+func weekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,50 @@
+package parse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelative(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2021, 3, 31, 15, 4, 5, 0, time.UTC)
+	for text, want := range map[string]time.Time{
+		"today":          utc(2021, 3, 31),
+		"Tomorrow":       utc(2021, 4, 1),
+		"yesterday":      utc(2021, 3, 30),
+		"wednesday":      utc(2021, 3, 31),
+		"friday":         utc(2021, 4, 2),
+		"next wed":       utc(2021, 4, 7),
+		"next friday":    utc(2021, 4, 2),
+		"last friday":    utc(2021, 3, 26),
+		"+3d":            utc(2021, 4, 3),
+		"-2w":            utc(2021, 3, 17),
+		"+1m":            utc(2021, 4, 30),
+		"-1y":            utc(2020, 3, 31),
+		"in 3 days":      utc(2021, 4, 3),
+		"2 weeks ago":    utc(2021, 3, 17),
+		"next month":     utc(2021, 4, 30),
+		"end of month":   utc(2021, 3, 31),
+		"start of month": utc(2021, 3, 1),
+		"start of week":  utc(2021, 3, 29),
+		"end of week":    utc(2021, 4, 4),
+		"end of year":    utc(2021, 12, 31),
+	} {
+		got, err := Relative(text, now)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("%q: want %v, got %v", text, want.Format("2006-01-02"), got.Format("2006-01-02"))
+		}
+	}
+	for _, text := range []string{"", "someday", "+d", "+3x", "next", "in x days", "middle of month"} {
+		if _, err := Relative(text, now); err == nil {
+			t.Fatalf("%q: want error", text)
+		}
+	}
+}
+
+func utc(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,10 @@
+// Package tzdata embeds the IANA time zone database in the program, such that `parse.Location`
+// and `value.Location` accept every zone even on hosts without a database of their own, such as
+// Windows without Go installed. Embedding adds about 450 KB to the program.
+//
+// Import it for its side effect, once, in package main:
+//
+//	import _ "git.sr.ht/~jackmordaunt/gio-planet/form/parse/tzdata"
+package tzdata
+
+import _ "time/tzdata" // Registers the embedded database with package time.
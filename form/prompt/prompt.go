@@ -0,0 +1,96 @@
+// Package prompt drives forms interactively over line oriented text streams, such as a terminal.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// Input implements `form.Input` as a line of text.
+type Input struct {
+	text string
+	err  string
+}
+
+func (in *Input) Text() string {
+	return in.text
+}
+
+func (in *Input) SetText(text string) {
+	in.text = text
+}
+
+func (in *Input) SetError(err string) {
+	in.err = err
+}
+
+func (in *Input) ClearError() {
+	in.err = ""
+}
+
+// Error returns the validation error, if any.
+func (in *Input) Error() string {
+	return in.err
+}
+
+// Run prompts for each field in turn, reading one line of input per prompt.
+//
+// The prompt displays the field label and the default text provided by the value. An empty line
+// accepts the default. Invalid input prints the error and prompts again until the field validates.
+// Fields without an `*Input` are given one.
+//
+// Returns `io.ErrUnexpectedEOF` if the input ends before every field is valid.
+func Run(in io.Reader, out io.Writer, f *form.Form) error {
+	lines := bufio.NewScanner(in)
+	for ii := range f.Fields {
+		field := &f.Fields[ii]
+		input, ok := field.Input.(*Input)
+		if !ok {
+			input = &Input{}
+			field.Input = input
+		}
+		def, err := field.Value.To()
+		if err != nil {
+			def = ""
+		}
+		for {
+			if def != "" {
+				fmt.Fprintf(out, "%s [%s]: ", label(field, ii), def)
+			} else {
+				fmt.Fprintf(out, "%s: ", label(field, ii))
+			}
+			if !lines.Scan() {
+				if err := lines.Err(); err != nil {
+					return fmt.Errorf("reading input: %w", err)
+				}
+				return io.ErrUnexpectedEOF
+			}
+			text := strings.TrimRight(lines.Text(), "\r")
+			if text == "" {
+				text = def
+			}
+			input.SetText(text)
+			if field.Validate() {
+				break
+			}
+			fmt.Fprintf(out, "  error: %s\n", input.Error())
+		}
+	}
+	return nil
+}
+
+// label picks the most descriptive name available for the field.
+func label(field *form.Field, index int) string {
+	switch {
+	case field.Label != "":
+		return field.Label
+	case field.Key != "":
+		return field.Key
+	default:
+		return fmt.Sprintf("Field %d", index+1)
+	}
+}
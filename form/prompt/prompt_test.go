@@ -0,0 +1,64 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/value"
+)
+
+type Person struct {
+	Age  int
+	Name string
+}
+
+func personForm(p *Person) *form.Form {
+	return &form.Form{
+		Fields: []form.Field{
+			{
+				Label: "Name",
+				Value: value.Required{Value: value.Text{Value: &p.Name}},
+			},
+			{
+				Label: "Age",
+				Value: value.Int{Value: &p.Age, Default: 18},
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	var (
+		p   Person
+		out bytes.Buffer
+		in  = strings.NewReader("\nJack\nold\n\n")
+	)
+	if err := Run(in, &out, personForm(&p)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (Person{Age: 18, Name: "Jack"}); p != want {
+		t.Fatalf("\nwant: %+v\n got: %+v", want, p)
+	}
+	want := strings.Join([]string{
+		"Name: ",
+		"  error: required\n",
+		"Name: Age [18]: ",
+		"  error: must be a valid number\n",
+		"Age [18]: ",
+	}, "")
+	if got := out.String(); got != want {
+		t.Fatalf("\nwant: %q\n got: %q", want, got)
+	}
+}
+
+func TestRunUnexpectedEOF(t *testing.T) {
+	var p Person
+	err := Run(strings.NewReader("\n"), io.Discard, personForm(&p))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("want %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+}
@@ -0,0 +1,298 @@
+// Package rules compiles validation rules written in a tag mini-language into a chain of
+// `form.Value` wrappers, for the familiar struct tag style:
+//
+//	v, err := rules.Compile("required,trim,min=3,max=50,email", value.Text{Value: &model.Email})
+//
+// A tag is a comma separated list of rules, each a name with an optional parameter after "=".
+// Commas and backslashes within a parameter are escaped with a backslash. Rules check text in
+// the order written, so "trim,min=3" counts the trimmed text. Rules that check the form of text,
+// such as email, accept empty text and leave that to required.
+//
+// Unknown rules and bad parameters are reported by Compile, so that a form with a mistaken tag
+// fails when it is built rather than when it is submitted.
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/value"
+)
+
+// Rule wraps a value with a constraint configured by the parameter, which is empty if the tag
+// has none.
+type Rule func(v form.Value, param string) (form.Value, error)
+
+// Registry maps rule names to rules. Register rules before compiling concurrently.
+type Registry map[string]Rule
+
+// Default contains the builtin rules:
+//
+//	required           rejects empty text
+//	min=n, max=n       bounds numbers, or the length of text in characters
+//	gt=n, lt=n         bounds numbers exclusively
+//	step=n             requires a multiple of n
+//	places=n           limits decimal places
+//	len=n              requires exactly n characters
+//	pattern=re         requires text to match the regular expression
+//	email, url, phone  requires an email address, a URL or a phone number
+//	oneof=a|b          requires one of the listed options
+//	alpha, alnum       requires letters, or letters and digits
+//	digits             requires digits
+//	trim, collapse     removes surrounding space, or collapses runs of space
+//	lower              folds case
+//
+// The gt, lt, step and places rules apply only to values that hold a number.
+var Default = Registry{
+	"required": func(v form.Value, param string) (form.Value, error) {
+		return value.Required{Value: v}, none(param)
+	},
+	"min": func(v form.Value, param string) (form.Value, error) {
+		if numeric(v) {
+			n, err := number(param)
+			return value.Min{Value: v, Min: n}, err
+		}
+		n, err := count(param)
+		return value.MinLength{Value: v, Min: n}, err
+	},
+	"max": func(v form.Value, param string) (form.Value, error) {
+		if numeric(v) {
+			n, err := number(param)
+			return value.Max{Value: v, Max: n}, err
+		}
+		n, err := count(param)
+		return value.MaxLength{Value: v, Max: n}, err
+	},
+	"gt": func(v form.Value, param string) (form.Value, error) {
+		if !numeric(v) {
+			return nil, errNotNumeric
+		}
+		n, err := number(param)
+		return value.Min{Value: v, Min: n, Exclusive: true}, err
+	},
+	"lt": func(v form.Value, param string) (form.Value, error) {
+		if !numeric(v) {
+			return nil, errNotNumeric
+		}
+		n, err := number(param)
+		return value.Max{Value: v, Max: n, Exclusive: true}, err
+	},
+	"step": func(v form.Value, param string) (form.Value, error) {
+		if !numeric(v) {
+			return nil, errNotNumeric
+		}
+		n, err := number(param)
+		if err == nil && n <= 0 {
+			err = fmt.Errorf("want positive number, got %q", param)
+		}
+		return value.Step{Value: v, Step: n}, err
+	},
+	"places": func(v form.Value, param string) (form.Value, error) {
+		if !numeric(v) {
+			return nil, errNotNumeric
+		}
+		n, err := count(param)
+		return value.Places{Value: v, Places: n}, err
+	},
+	"len": func(v form.Value, param string) (form.Value, error) {
+		n, err := count(param)
+		return value.MaxLength{Value: value.MinLength{Value: v, Min: n}, Max: n}, err
+	},
+	"pattern": func(v form.Value, param string) (form.Value, error) {
+		re, err := regexp.Compile(param)
+		if err != nil {
+			return nil, err
+		}
+		return value.Pattern{Value: v, Pattern: re}, nil
+	},
+	"email": func(v form.Value, param string) (form.Value, error) {
+		return check{Value: v, Check: func(text string) error {
+			_, err := parse.Email(text)
+			return err
+		}, Format: "email"}, none(param)
+	},
+	"url": func(v form.Value, param string) (form.Value, error) {
+		var schemes []string
+		if param != "" {
+			schemes = strings.Split(param, "|")
+		}
+		return check{Value: v, Check: func(text string) error {
+			_, err := parse.URL(text, schemes...)
+			return err
+		}, Format: "uri"}, nil
+	},
+	"phone": func(v form.Value, region string) (form.Value, error) {
+		return check{Value: v, Check: func(text string) error {
+			_, err := parse.Phone(text, region)
+			return err
+		}}, nil
+	},
+	"oneof": func(v form.Value, param string) (form.Value, error) {
+		if param == "" {
+			return nil, fmt.Errorf("want options separated by |")
+		}
+		options := strings.Split(param, "|")
+		return check{Value: v, Check: func(text string) error {
+			for _, option := range options {
+				if text == option {
+					return nil
+				}
+			}
+			return fmt.Errorf("must be one of %s", strings.Join(options, ", "))
+		}}, nil
+	},
+	"alpha": func(v form.Value, param string) (form.Value, error) {
+		return value.Charset{Value: v, Classes: []*unicode.RangeTable{unicode.Letter}, Name: "letters"}, none(param)
+	},
+	"alnum": func(v form.Value, param string) (form.Value, error) {
+		return value.Charset{Value: v, Classes: []*unicode.RangeTable{unicode.Letter, unicode.Digit}, Name: "letters and digits"}, none(param)
+	},
+	"digits": func(v form.Value, param string) (form.Value, error) {
+		return value.Charset{Value: v, Classes: []*unicode.RangeTable{unicode.Digit}, Name: "digits"}, none(param)
+	},
+	"trim": func(v form.Value, param string) (form.Value, error) {
+		return value.Normalize{Value: v, Trim: true}, none(param)
+	},
+	"collapse": func(v form.Value, param string) (form.Value, error) {
+		return value.Normalize{Value: v, Collapse: true}, none(param)
+	},
+	"lower": func(v form.Value, param string) (form.Value, error) {
+		return value.Normalize{Value: v, Fold: true}, none(param)
+	},
+}
+
+// Register adds a rule to the default registry, replacing any rule of the same name.
+func Register(name string, rule Rule) {
+	Default[name] = rule
+}
+
+// Compile wraps v with the rules of the tag, using the default registry.
+func Compile(tag string, v form.Value) (form.Value, error) {
+	return Default.Compile(tag, v)
+}
+
+// Compile wraps v with the rules of the tag.
+func (r Registry) Compile(tag string, v form.Value) (form.Value, error) {
+	entries, err := split(tag)
+	if err != nil {
+		return nil, err
+	}
+	// Wrap from last to first, such that the first rule is outermost and checks text first.
+	for ii := len(entries) - 1; ii >= 0; ii-- {
+		name, param, _ := strings.Cut(entries[ii], "=")
+		name = strings.TrimSpace(name)
+		rule, ok := r[name]
+		if !ok {
+			return nil, fmt.Errorf("rules: unknown rule %q", name)
+		}
+		if v, err = rule(v, param); err != nil {
+			return nil, fmt.Errorf("rules: %s: %w", name, err)
+		}
+	}
+	return v, nil
+}
+
+// split the tag into rules at unescaped commas.
+func split(tag string) ([]string, error) {
+	if strings.TrimSpace(tag) == "" {
+		return nil, nil
+	}
+	var (
+		entries []string
+		entry   strings.Builder
+	)
+	for ii := 0; ii < len(tag); ii++ {
+		switch c := tag[ii]; {
+		case c == '\\' && ii+1 < len(tag) && (tag[ii+1] == ',' || tag[ii+1] == '\\'):
+			ii++
+			entry.WriteByte(tag[ii])
+		case c == ',':
+			entries = append(entries, entry.String())
+			entry.Reset()
+		default:
+			entry.WriteByte(c)
+		}
+	}
+	entries = append(entries, entry.String())
+	for ii, e := range entries {
+		if strings.TrimSpace(e) == "" {
+			return nil, fmt.Errorf("rules: empty rule at position %d in %q", ii+1, tag)
+		}
+	}
+	return entries, nil
+}
+
+// numeric reports whether v, or any value it wraps, holds a number.
+func numeric(v form.Value) bool {
+	for v != nil {
+		if _, ok := v.(value.Numeric); ok {
+			return true
+		}
+		unwrapper, ok := v.(form.Unwrapper)
+		if !ok {
+			break
+		}
+		v = unwrapper.Unwrap()
+	}
+	return false
+}
+
+// errNotNumeric rejects number rules on values that do not hold a number.
+var errNotNumeric = fmt.Errorf("applies only to numbers")
+
+func none(param string) error {
+	if param != "" {
+		return fmt.Errorf("want no parameter, got %q", param)
+	}
+	return nil
+}
+
+func number(param string) (float64, error) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return 0, fmt.Errorf("want number, got %q", param)
+	}
+	return n, nil
+}
+
+func count(param string) (int, error) {
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("want count, got %q", param)
+	}
+	return n, nil
+}
+
+// check rejects non-empty text that fails a check before the value parses it.
+type check struct {
+	form.Value
+	Check func(text string) error
+	// Format is the JSON Schema format of the checked text, if any.
+	Format string
+}
+
+func (c check) From(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return c.Value.From(text)
+	}
+	if err := c.Check(text); err != nil {
+		return err
+	}
+	return c.Value.From(text)
+}
+
+func (c check) Unwrap() form.Value {
+	return c.Value
+}
+
+func (c check) Schema(s form.Schema) {
+	form.Describe(c.Value, s)
+	if c.Format != "" {
+		s["format"] = c.Format
+	}
+}
@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/value"
+)
+
+func TestCompile(t *testing.T) {
+	for _, tt := range []struct {
+		tag  string
+		text string
+		want string
+	}{
+		{"required,min=3,max=5", "", "required"},
+		{"required,min=3,max=5", "ab", "must be at least 3 characters"},
+		{"required,min=3,max=5", "abcdef", "must be at most 5 characters"},
+		{"required,min=3,max=5", "abcd", ""},
+		{"trim,min=3", "  ab  ", "must be at least 3 characters"},
+		{"len=2", "abc", "must be at most 2 characters"},
+		{"email", "jack", "must be an email address such as name@example.com"},
+		{"email", "", ""},
+		{"url=https", "http://example.com", "must start with https://"},
+		{"oneof=red|green", "blue", "must be one of red, green"},
+		{"oneof=red|green", "green", ""},
+		{`pattern=^[a-z]{1\,3}$`, "abcd", "must match ^[a-z]{1,3}$"},
+		{"alnum", "a-b", `must contain only letters and digits, not '-'`},
+		{"phone=AU", "0412 345 678", ""},
+	} {
+		var s string
+		v, err := Compile(tt.tag, value.Text{Value: &s})
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.tag, err)
+		}
+		got := ""
+		if err := v.From(tt.text); err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Fatalf("%q: %q: want %q, got %q", tt.tag, tt.text, tt.want, got)
+		}
+	}
+}
+
+func TestCompileNumeric(t *testing.T) {
+	var n int
+	v, err := Compile("required,min=1,lt=10,step=2", value.Int{Value: &n})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for text, want := range map[string]string{
+		"0":  "must be at least 1",
+		"10": "must be less than 10",
+		"3":  "must be a multiple of 2",
+		"4":  "",
+	} {
+		got := ""
+		if err := v.From(text); err != nil {
+			got = err.Error()
+		}
+		if got != want {
+			t.Fatalf("%q: want %q, got %q", text, want, got)
+		}
+	}
+	if !form.IsRequired(v) {
+		t.Fatalf("want required")
+	}
+	s := form.Schema{}
+	form.Describe(v, s)
+	if s["minimum"] != 1.0 || s["exclusiveMaximum"] != 10.0 || s["multipleOf"] != 2.0 {
+		t.Fatalf("unexpected schema: %v", s)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for tag, want := range map[string]string{
+		"required,nope":   `rules: unknown rule "nope"`,
+		"min=three":       `rules: min: want number, got "three"`,
+		"max=-1":          `rules: max: want count, got "-1"`,
+		"required=yes":    `rules: required: want no parameter, got "yes"`,
+		"pattern=[":       "rules: pattern: error parsing regexp: missing closing ]: `[`",
+		"step=0":          `rules: step: want positive number, got "0"`,
+		"required,,min=1": `rules: empty rule at position 2 in "required,,min=1"`,
+		"oneof":           "rules: oneof: want options separated by |",
+		"gt=3":            "rules: gt: applies only to numbers",
+		"lt=3":            "rules: lt: applies only to numbers",
+		"step=2":          "rules: step: applies only to numbers",
+		"places=2":        "rules: places: applies only to numbers",
+	} {
+		var s string
+		v := form.Value(value.Text{Value: &s})
+		if tag == "min=three" || tag == "step=0" {
+			var n int
+			v = value.Int{Value: &n}
+		}
+		_, err := Compile(tag, v)
+		if err == nil || err.Error() != want {
+			t.Fatalf("%q: want %q, got %v", tag, want, err)
+		}
+	}
+	if v, err := Compile(" ", value.Text{Value: new(string)}); err != nil || v == nil {
+		t.Fatalf("want empty tag to return the value, got %v, %v", v, err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	r := Registry{
+		"prefix": func(v form.Value, param string) (form.Value, error) {
+			return value.All{v, value.Check(func(text string) error {
+				if !strings.HasPrefix(text, param) {
+					return fmt.Errorf("must start with %s", param)
+				}
+				return nil
+			})}, nil
+		},
+	}
+	var s string
+	v, err := r.Compile("prefix=INV-", value.Text{Value: &s})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := v.From("PO-1"); err == nil || err.Error() != "must start with INV-" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.Compile("required", value.Text{Value: &s}); err == nil {
+		t.Fatalf("want registries to be independent")
+	}
+}
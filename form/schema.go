@@ -0,0 +1,88 @@
+package form
+
+// Draft is the JSON Schema dialect emitted by `Form.Schema`.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document, or a fragment of one, ready to be marshalled with
+// `encoding/json`.
+type Schema map[string]interface{}
+
+// Schemer is implemented by values that can describe the data they accept.
+type Schemer interface {
+	// Schema adds keywords describing the value to the fragment.
+	Schema(s Schema)
+}
+
+// Requirer is implemented by values that reject empty input.
+type Requirer interface {
+	Required() bool
+}
+
+// Unwrapper is implemented by values that wrap another value.
+// Wrappers that don't describe themselves inherit the description of the value they wrap.
+type Unwrapper interface {
+	Unwrap() Value
+}
+
+// Describe adds the schema fragment for v to s.
+// Values that are neither a `Schemer` nor an `Unwrapper` are left undescribed.
+func Describe(v Value, s Schema) {
+	for v != nil {
+		if schemer, ok := v.(Schemer); ok {
+			schemer.Schema(s)
+			return
+		}
+		unwrapper, ok := v.(Unwrapper)
+		if !ok {
+			return
+		}
+		v = unwrapper.Unwrap()
+	}
+}
+
+// IsRequired reports whether v, or any value it wraps, rejects empty input.
+func IsRequired(v Value) bool {
+	for v != nil {
+		if requirer, ok := v.(Requirer); ok && requirer.Required() {
+			return true
+		}
+		unwrapper, ok := v.(Unwrapper)
+		if !ok {
+			return false
+		}
+		v = unwrapper.Unwrap()
+	}
+	return false
+}
+
+// Schema describes the fields as a JSON Schema object, where each property is named by the field
+// key and titled by the field label. Fields without a key are omitted.
+func (f *Form) Schema() Schema {
+	var (
+		properties = Schema{}
+		required   = []string{}
+	)
+	for _, field := range f.Fields {
+		if field.Key == "" {
+			continue
+		}
+		property := Schema{}
+		if field.Label != "" {
+			property["title"] = field.Label
+		}
+		Describe(field.Value, property)
+		properties[field.Key] = property
+		if IsRequired(field.Value) {
+			required = append(required, field.Key)
+		}
+	}
+	s := Schema{
+		"$schema":    Draft,
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package form_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/value"
+)
+
+// Even is a custom value that contributes its own schema fragment.
+type Even struct {
+	value.Int
+}
+
+func (v Even) Schema(s form.Schema) {
+	v.Int.Schema(s)
+	s["multipleOf"] = 2
+}
+
+func TestFormSchema(t *testing.T) {
+	var model struct {
+		Age    int
+		Name   string
+		Salary float64
+		Born   time.Time
+		Issued time.Time
+		Pairs  int
+	}
+	f := form.Form{
+		Fields: []form.Field{
+			{Key: "age", Value: value.Int{Value: &model.Age, Default: 18}},
+			{Key: "name", Label: "Name", Value: value.Required{Value: value.Text{Value: &model.Name}}},
+			{Key: "salary", Value: value.Float{Value: &model.Salary}},
+			{Key: "born", Value: value.Date{Value: &model.Born}},
+			{Key: "issued", Value: value.Date{Value: &model.Issued, Layouts: []string{parse.ISO}}},
+			{Key: "pairs", Value: Even{value.Int{Value: &model.Pairs}}},
+			{Value: value.Text{Value: &model.Name}},
+		},
+	}
+	got, err := json.Marshal(f.Schema())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema",` +
+		`"properties":{` +
+		`"age":{"default":18,"type":"integer"},` +
+		`"born":{"type":"string"},` +
+		`"issued":{"format":"date","type":"string"},` +
+		`"name":{"title":"Name","type":"string"},` +
+		`"pairs":{"multipleOf":2,"type":"integer"},` +
+		`"salary":{"type":"number"}},` +
+		`"required":["name"],"type":"object"}`
+	if string(got) != want {
+		t.Fatalf("\nwant: %s\n got: %s", want, got)
+	}
+}
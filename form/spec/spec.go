@@ -0,0 +1,309 @@
+// Package spec builds forms at runtime from field specifications, for screens whose fields are
+// configured rather than known at compile time.
+//
+// Specifications are either a list of fields in JSON, or a JSON Schema object whose properties
+// describe the fields.
+//
+//	[
+//		{"key": "name", "label": "Name", "type": "string", "required": true},
+//		{"key": "age", "label": "Age", "type": "integer", "minimum": 0, "default": 18}
+//	]
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/value"
+)
+
+// Type names the kind of data a field accepts.
+type Type string
+
+const (
+	String  Type = "string"
+	Integer Type = "integer"
+	Number  Type = "number"
+	Date    Type = "date"
+)
+
+// Field specifies a form field.
+type Field struct {
+	Key       string   `json:"key"`
+	Label     string   `json:"label,omitempty"`
+	Type      Type     `json:"type"`
+	Required  bool     `json:"required,omitempty"`
+	Default   any      `json:"default,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+}
+
+// Parse decodes a JSON list of field specifications.
+func Parse(data []byte) ([]Field, error) {
+	var fields []Field
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("decoding fields: %w", err)
+	}
+	return fields, nil
+}
+
+// FromSchema decodes the properties of a JSON Schema object into field specifications, in
+// document order.
+func FromSchema(data []byte) ([]Field, error) {
+	var schema struct {
+		Properties json.RawMessage `json:"properties"`
+		Required   []string        `json:"required"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("decoding schema: %w", err)
+	}
+	var properties map[string]struct {
+		Type      string   `json:"type"`
+		Format    string   `json:"format"`
+		Title     string   `json:"title"`
+		Default   any      `json:"default"`
+		Minimum   *float64 `json:"minimum"`
+		Maximum   *float64 `json:"maximum"`
+		MinLength *int     `json:"minLength"`
+		MaxLength *int     `json:"maxLength"`
+		Pattern   string   `json:"pattern"`
+	}
+	if err := json.Unmarshal(schema.Properties, &properties); err != nil {
+		return nil, fmt.Errorf("decoding properties: %w", err)
+	}
+	keys, err := objectKeys(schema.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("decoding properties: %w", err)
+	}
+	required := map[string]bool{}
+	for _, key := range schema.Required {
+		required[key] = true
+	}
+	fields := make([]Field, 0, len(keys))
+	for _, key := range keys {
+		p := properties[key]
+		t := Type(p.Type)
+		if t == String && p.Format == "date" {
+			t = Date
+		}
+		fields = append(fields, Field{
+			Key:       key,
+			Label:     p.Title,
+			Type:      t,
+			Required:  required[key],
+			Default:   p.Default,
+			Minimum:   p.Minimum,
+			Maximum:   p.Maximum,
+			MinLength: p.MinLength,
+			MaxLength: p.MaxLength,
+			Pattern:   p.Pattern,
+		})
+	}
+	return fields, nil
+}
+
+// objectKeys lists the keys of a JSON object in the order they appear.
+func objectKeys(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected object")
+	}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, tok.(string))
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// Build loads a form with a field per specification.
+//
+// Validated values are stored into the model by key: `string` for text, `int` for integers,
+// `float64` for numbers and `time.Time` for dates. Existing model entries are displayed in place of
+// the default. Inputs are allocated by calling input once per field, for instance returning a new
+// `component.TextField`.
+func Build(fields []Field, model map[string]any, input func(Field) form.Input) (*form.Form, error) {
+	built := make([]form.Field, 0, len(fields))
+	for _, field := range fields {
+		v, err := field.value(model)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field.Key, err)
+		}
+		if field.Required {
+			v = value.Required{Value: v}
+		}
+		built = append(built, form.Field{
+			Key:   field.Key,
+			Label: field.Label,
+			Value: v,
+			Input: input(field),
+		})
+	}
+	var f form.Form
+	f.Load(built)
+	return &f, nil
+}
+
+// value creates a `form.Value` for the field that stores into the model.
+func (field Field) value(model map[string]any) (form.Value, error) {
+	if field.Key == "" {
+		return nil, fmt.Errorf("missing key")
+	}
+	initial, ok := model[field.Key]
+	if !ok {
+		initial = field.Default
+	}
+	switch field.Type {
+	case String:
+		var s string
+		if initial != nil {
+			s, ok = initial.(string)
+			if !ok {
+				return nil, fmt.Errorf("default: want string, got %T", initial)
+			}
+		}
+		var v form.Value = value.Text{Value: &s}
+		if field.Pattern != "" {
+			p, err := regexp.Compile(field.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("pattern: %w", err)
+			}
+			v = value.Pattern{Value: v, Pattern: p}
+		}
+		if field.MinLength != nil {
+			v = value.MinLength{Value: v, Min: *field.MinLength}
+		}
+		if field.MaxLength != nil {
+			v = value.MaxLength{Value: v, Max: *field.MaxLength}
+		}
+		return field.store(model, v, &s), nil
+	case Integer:
+		var n int
+		if initial != nil {
+			f, ok := number(initial)
+			if !ok || f != math.Trunc(f) {
+				return nil, fmt.Errorf("default: want integer, got %v", initial)
+			}
+			n = int(f)
+		}
+		return field.store(model, field.bounds(value.Int{Value: &n, Default: n}), &n), nil
+	case Number:
+		var n float64
+		if initial != nil {
+			if n, ok = number(initial); !ok {
+				return nil, fmt.Errorf("default: want number, got %v", initial)
+			}
+		}
+		return field.store(model, field.bounds(value.Float{Value: &n}), &n), nil
+	case Date:
+		var t time.Time
+		switch initial := initial.(type) {
+		case nil:
+		case time.Time:
+			t = initial
+		case string:
+			d, err := time.ParseInLocation("2006-01-02", initial, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("default: %w", err)
+			}
+			t = d
+		default:
+			return nil, fmt.Errorf("default: want date, got %T", initial)
+		}
+		// Dates are ISO 8601, as the JSON Schema "date" format describes.
+		return field.store(model, value.Date{Value: &t, Default: t, Layouts: []string{parse.ISO}}, &t), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %q", field.Type)
+	}
+}
+
+// bounds constrains v to the minimum and maximum of the field.
+func (field Field) bounds(v form.Value) form.Value {
+	if field.Minimum != nil {
+		v = value.Min{Value: v, Min: *field.Minimum}
+	}
+	if field.Maximum != nil {
+		v = value.Max{Value: v, Max: *field.Maximum}
+	}
+	return v
+}
+
+// store wraps v such that the variable it points to is copied into the model once validated.
+func (field Field) store(model map[string]any, v form.Value, ptr any) form.Value {
+	return entry{
+		Value: v,
+		store: func() {
+			switch ptr := ptr.(type) {
+			case *string:
+				model[field.Key] = *ptr
+			case *int:
+				model[field.Key] = *ptr
+			case *float64:
+				model[field.Key] = *ptr
+			case *time.Time:
+				model[field.Key] = *ptr
+			}
+		},
+		clear: func() {
+			delete(model, field.Key)
+		},
+	}
+}
+
+// number converts decoded JSON numbers and Go numbers to float64.
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// entry stores the result of a value into a map model.
+type entry struct {
+	form.Value
+	store func()
+	clear func()
+}
+
+func (e entry) From(text string) error {
+	if err := e.Value.From(text); err != nil {
+		return err
+	}
+	e.store()
+	return nil
+}
+
+func (e entry) Clear() {
+	e.Value.Clear()
+	e.clear()
+}
+
+func (e entry) Unwrap() form.Value {
+	return e.Value
+}
@@ -0,0 +1,131 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// Input is a minimal `form.Input`.
+type Input struct {
+	text string
+	err  string
+}
+
+func (in *Input) Text() string        { return in.text }
+func (in *Input) SetText(text string) { in.text = text }
+func (in *Input) SetError(err string) { in.err = err }
+func (in *Input) ClearError()         { in.err = "" }
+
+func TestFromSchema(t *testing.T) {
+	fields, err := FromSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "title": "Name", "maxLength": 5},
+			"age": {"type": "integer", "minimum": 0, "default": 18},
+			"born": {"type": "string", "format": "date"}
+		},
+		"required": ["name"]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var (
+		zero = 0.0
+		five = 5
+		want = []Field{
+			{Key: "name", Label: "Name", Type: String, Required: true, MaxLength: &five},
+			{Key: "age", Type: Integer, Default: 18.0, Minimum: &zero},
+			{Key: "born", Type: Date},
+		}
+	)
+	if !reflect.DeepEqual(want, fields) {
+		t.Fatalf("\nwant: %+v\n got: %+v", want, fields)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	fields, err := Parse([]byte(`[
+		{"key": "name", "type": "string", "required": true, "maxLength": 5},
+		{"key": "age", "type": "integer", "minimum": 0, "default": 18},
+		{"key": "salary", "type": "number"}
+	]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var (
+		model  = map[string]any{"salary": 10.5}
+		inputs = map[string]*Input{}
+	)
+	f, err := Build(fields, model, func(field Field) form.Input {
+		inputs[field.Key] = &Input{}
+		return inputs[field.Key]
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := inputs["age"].text; got != "18" {
+		t.Fatalf("want default age 18, got %q", got)
+	}
+	if got := inputs["salary"].text; got != "10.50" {
+		t.Fatalf("want salary from model, got %q", got)
+	}
+	inputs["name"].text = "Jackson"
+	inputs["age"].text = "-1"
+	if f.Submit() {
+		t.Fatalf("want submit to fail")
+	}
+	if inputs["name"].err != "must be at most 5 characters" || inputs["age"].err != "must be at least 0" {
+		t.Fatalf("unexpected errors: %q %q", inputs["name"].err, inputs["age"].err)
+	}
+	inputs["name"].text = "Jack"
+	inputs["age"].text = "42"
+	if !f.Submit() {
+		t.Fatalf("want submit to succeed")
+	}
+	want := map[string]any{"name": "Jack", "age": 42, "salary": 10.5}
+	if !reflect.DeepEqual(want, model) {
+		t.Fatalf("\nwant: %v\n got: %v", want, model)
+	}
+}
+
+func TestBuildDate(t *testing.T) {
+	var (
+		model = map[string]any{}
+		in    = &Input{}
+	)
+	f, err := Build([]Field{{Key: "born", Type: Date, Default: "2020-01-31"}}, model, func(Field) form.Input {
+		return in
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if in.text != "2020-01-31" {
+		t.Fatalf("want ISO default, got %q", in.text)
+	}
+	if s := f.Schema()["properties"].(form.Schema)["born"].(form.Schema); s["format"] != "date" {
+		t.Fatalf("want date format, got %v", s)
+	}
+	in.text = "2020-02-01"
+	if !f.Submit() {
+		t.Fatalf("unexpected error: %q", in.err)
+	}
+	if got := model["born"].(time.Time); got.Month() != time.February || got.Day() != 1 {
+		t.Fatalf("want ISO date stored, got %v", got)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	for _, fields := range [][]Field{
+		{{Key: "a", Type: "color"}},
+		{{Type: String}},
+		{{Key: "a", Type: String, Pattern: "("}},
+		{{Key: "a", Type: Integer, Default: 1.5}},
+	} {
+		if _, err := Build(fields, map[string]any{}, func(Field) form.Input { return &Input{} }); err == nil {
+			t.Fatalf("want error for %+v", fields)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package value
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// TimeOfDay maps text to the time since midnight, such as "3pm" or "15:00".
+// "now" reads the clock.
+type TimeOfDay struct {
+	Value *time.Duration
+	// Clock12 displays the time on a 12 hour clock, such as "3:00 PM".
+	Clock12 bool
+	// Now is the clock that "now" is evaluated against. Defaults to `time.Now`.
+	Now func() time.Time
+}
+
+func (v TimeOfDay) To() (string, error) {
+	return parse.FormatTimeOfDay(*v.Value, v.Clock12), nil
+}
+
+func (v TimeOfDay) From(text string) error {
+	if strings.EqualFold(strings.TrimSpace(text), "now") {
+		now := clock(v.Now)
+		*v.Value = time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+		return nil
+	}
+	d, err := parse.TimeOfDay(text)
+	if err != nil {
+		return err
+	}
+	*v.Value = d
+	return nil
+}
+
+func (v TimeOfDay) Clear() {
+	*v.Value = 0
+}
+
+func (v TimeOfDay) Schema(s form.Schema) {
+	s["type"] = "string"
+}
+
+// DateTime maps text to an instant, written as a date followed by a time of day and optionally a
+// time zone, such as "1/3/2021 3pm", "2021-03-01 15:00 Europe/Berlin" or "1/3/2021 15:00 +01:00".
+// A date alone is midnight. "now" reads the clock.
+type DateTime struct {
+	Value *time.Time
+	// Location interprets text without a time zone and displays the instant.
+	// Defaults to `time.Local`.
+	Location *time.Location
+	// Layouts accepted for the date, in order of preference. Defaults to `parse.DMY`.
+	Layouts []string
+	// Display is the layout used to display the date. Defaults to the first layout.
+	Display string
+	// Clock12 displays the time on a 12 hour clock.
+	Clock12 bool
+	// Zone displays the instant in its own time zone, followed by the zone, rather than converting
+	// it to Location.
+	Zone bool
+	// Now is the clock that "now" and Clear are evaluated against. Defaults to `time.Now`.
+	Now func() time.Time
+}
+
+func (v DateTime) To() (string, error) {
+	t := *v.Value
+	if t.IsZero() {
+		return "", nil
+	}
+	if !v.Zone {
+		t = t.In(v.location())
+	}
+	text := parse.FormatDate(t, Date{Layouts: v.Layouts, Display: v.Display}.display()) + " " +
+		parse.FormatTimeOfDay(sinceMidnight(t), v.Clock12)
+	if v.Zone {
+		if name := t.Location().String(); name != "Local" {
+			text += " " + name
+		} else {
+			text += " " + t.Format("-07:00")
+		}
+	}
+	return text, nil
+}
+
+func (v DateTime) From(text string) error {
+	var (
+		fields = strings.Fields(text)
+		loc    = v.location()
+	)
+	if len(fields) == 1 && strings.EqualFold(fields[0], "now") {
+		*v.Value = clock(v.Now).In(loc).Truncate(time.Second)
+		return nil
+	}
+	if n := len(fields); n > 2 {
+		if zone, err := parse.Zone(fields[n-1]); err == nil {
+			loc, fields = zone, fields[:n-1]
+		}
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf(`must be a date and time such as "1/3/2021 3pm"`)
+	}
+	var (
+		parser  = parse.DateParser{Layouts: v.Layouts, Location: loc}
+		dateErr error
+		timeErr error
+	)
+	// The boundary between date and time is ambiguous when either contains spaces, so try each.
+	for split := len(fields); split > 0; split-- {
+		date, err := parser.Parse(strings.Join(fields[:split], " "))
+		if err != nil {
+			if dateErr == nil || split == 1 {
+				dateErr = err
+			}
+			continue
+		}
+		var tod time.Duration
+		if split < len(fields) {
+			tod, err = parse.TimeOfDay(strings.Join(fields[split:], " "))
+			if err != nil {
+				timeErr = err
+				continue
+			}
+		}
+		*v.Value = time.Date(
+			date.Year(), date.Month(), date.Day(),
+			int(tod/time.Hour), int(tod%time.Hour/time.Minute), int(tod%time.Minute/time.Second),
+			0, loc)
+		return nil
+	}
+	if timeErr != nil {
+		return timeErr
+	}
+	return dateErr
+}
+
+func (v DateTime) Clear() {
+	*v.Value = clock(v.Now).In(v.location()).Truncate(time.Second)
+}
+
+func (v DateTime) Schema(s form.Schema) {
+	s["type"] = "string"
+}
+
+func (v DateTime) location() *time.Location {
+	if v.Location == nil {
+		return time.Local
+	}
+	return v.Location
+}
+
+// Location maps an IANA time zone name, such as "Australia/Sydney", to a location.
+// See `parse.Location` for which zones are accepted.
+type Location struct {
+	Value **time.Location
+}
+
+func (v Location) To() (string, error) {
+	if *v.Value == nil {
+		return "", nil
+	}
+	return (*v.Value).String(), nil
+}
+
+func (v Location) From(text string) error {
+	loc, err := parse.Location(text)
+	if err != nil {
+		return err
+	}
+	*v.Value = loc
+	return nil
+}
+
+func (v Location) Clear() {
+	*v.Value = nil
+}
+
+func (v Location) Schema(s form.Schema) {
+	s["type"] = "string"
+}
+
+// sinceMidnight is the time of day of t.
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+}
+
+// clock reads an injectable clock, defaulting to `time.Now`.
+func clock(now func() time.Time) time.Time {
+	if now != nil {
+		return now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,82 @@
+package value
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	var (
+		d time.Duration
+		v = TimeOfDay{
+			Value:   &d,
+			Clock12: true,
+			Now:     func() time.Time { return time.Date(2021, 3, 1, 9, 41, 5, 0, time.UTC) },
+		}
+	)
+	if err := v.From("15:30"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "3:30 PM" {
+		t.Fatalf("want %q, got %q", "3:30 PM", text)
+	}
+	if err := v.From("now"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 9*time.Hour + 41*time.Minute; d != want {
+		t.Fatalf("want %v, got %v", want, d)
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	sydney, _ := time.LoadLocation("Australia/Sydney")
+	var (
+		when time.Time
+		v    = DateTime{Value: &when, Location: sydney, Layouts: []string{"yyyy-mm-dd"}}
+	)
+	for text, want := range map[string]time.Time{
+		"2021-03-01 3pm":             time.Date(2021, 3, 1, 15, 0, 0, 0, sydney),
+		"2021-03-01 3 pm":            time.Date(2021, 3, 1, 15, 0, 0, 0, sydney),
+		"2021-03-01":                 time.Date(2021, 3, 1, 0, 0, 0, 0, sydney),
+		"2021-03-01 15:00 UTC":       time.Date(2021, 3, 1, 15, 0, 0, 0, time.UTC),
+		"2021-03-01 15:00 +01:00":    time.Date(2021, 3, 1, 14, 0, 0, 0, time.UTC),
+		"2021-07-01 9am Europe/Rome": time.Date(2021, 7, 1, 7, 0, 0, 0, time.UTC),
+	} {
+		if err := v.From(text); err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if !when.Equal(want) {
+			t.Fatalf("%q: want %v, got %v", text, want, when)
+		}
+	}
+	if err := v.From("2021-03-01 15:00 UTC"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "2021-03-02 02:00" {
+		t.Fatalf("want %q, got %q", "2021-03-02 02:00", text)
+	}
+	v.Zone = true
+	if text, _ := v.To(); text != "2021-03-01 15:00 UTC" {
+		t.Fatalf("want %q, got %q", "2021-03-01 15:00 UTC", text)
+	}
+	if err := v.From("2021-02-30 3pm"); err == nil || err.Error() != "day must be between 1 and 28 for February 2021: 30" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := v.From("2021-03-01 25:00"); err == nil || err.Error() != "hour must be between 0 and 23: 25" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLocation(t *testing.T) {
+	var loc *time.Location
+	v := Location{Value: &loc}
+	if err := v.From("Asia/Tokyo"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "Asia/Tokyo" {
+		t.Fatalf("want %q, got %q", "Asia/Tokyo", text)
+	}
+	if err := v.From("Asia/Atlantis"); err == nil {
+		t.Fatalf("want error")
+	}
+}
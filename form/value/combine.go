@@ -0,0 +1,282 @@
+package value
+
+import (
+	"fmt"
+	"strings"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// Func builds a value from plain functions. Nil functions do nothing.
+type Func struct {
+	Format func() (string, error)
+	Parse  func(text string) error
+	Reset  func()
+}
+
+func (v Func) To() (string, error) {
+	if v.Format == nil {
+		return "", nil
+	}
+	return v.Format()
+}
+
+func (v Func) From(text string) error {
+	if v.Parse == nil {
+		return nil
+	}
+	return v.Parse(text)
+}
+
+func (v Func) Clear() {
+	if v.Reset != nil {
+		v.Reset()
+	}
+}
+
+// Check validates text without storing it, for use with `All`.
+type Check func(text string) error
+
+func (c Check) To() (string, error) {
+	return "", nil
+}
+
+func (c Check) From(text string) error {
+	return c(text)
+}
+
+func (c Check) Clear() {}
+
+func (c Check) validate() {}
+
+// validator is implemented by values that check text without storing it.
+type validator interface {
+	validate()
+}
+
+// All maps text through each value in turn, such that every value must accept it.
+// The first value formats text and describes the schema.
+//
+// Values that only validate, `Check` and `Not`, run before the others, so that text they reject
+// is never stored. Here name is left untouched unless reserved accepts the text:
+//
+//	value.All{value.Text{Value: &name}, value.Check(reserved)}
+//
+// Values that store run in order, so a later one rejecting text does not undo an earlier store.
+type All []form.Value
+
+func (all All) To() (string, error) {
+	if len(all) == 0 {
+		return "", nil
+	}
+	return all[0].To()
+}
+
+func (all All) From(text string) error {
+	for _, v := range all {
+		if _, ok := v.(validator); ok {
+			if err := v.From(text); err != nil {
+				return err
+			}
+		}
+	}
+	for _, v := range all {
+		if _, ok := v.(validator); !ok {
+			if err := v.From(text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (all All) Clear() {
+	for _, v := range all {
+		v.Clear()
+	}
+}
+
+func (all All) Required() bool {
+	for _, v := range all {
+		if form.IsRequired(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (all All) Schema(s form.Schema) {
+	if len(all) > 0 {
+		form.Describe(all[0], s)
+	}
+}
+
+// Any accepts text with the first value that parses it, such as a date written as either a
+// date or a relative day. If none do, the error of the first is reported.
+// Text is formatted by the first value with something to display.
+type Any []form.Value
+
+func (a Any) To() (string, error) {
+	for _, v := range a {
+		text, err := v.To()
+		if err != nil {
+			return "", err
+		}
+		if text != "" {
+			return text, nil
+		}
+	}
+	return "", nil
+}
+
+func (a Any) From(text string) error {
+	var first error
+	for _, v := range a {
+		err := v.From(text)
+		if err == nil {
+			return nil
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func (a Any) Clear() {
+	for _, v := range a {
+		v.Clear()
+	}
+}
+
+func (a Any) Schema(s form.Schema) {
+	var options []any
+	for _, v := range a {
+		option := form.Schema{}
+		form.Describe(v, option)
+		options = append(options, option)
+	}
+	s["anyOf"] = options
+}
+
+// Not rejects text that the check accepts, such as a username that looks like an email address.
+// Like `Check` it validates without storing.
+//
+//	value.Not{Check: func(text string) error { _, err := parse.Email(text); return err }}
+type Not struct {
+	Check func(text string) error
+	// Message describes the rejected text, such as "must not be an email address".
+	Message string
+}
+
+func (v Not) To() (string, error) {
+	return "", nil
+}
+
+func (v Not) From(text string) error {
+	if err := v.Check(text); err == nil {
+		if v.Message == "" {
+			return fmt.Errorf("not allowed")
+		}
+		return fmt.Errorf("%s", v.Message)
+	}
+	return nil
+}
+
+func (v Not) Clear() {}
+
+func (v Not) validate() {}
+
+// Transform rewrites text before the value parses it. See `Normalize` for common rewrites.
+type Transform struct {
+	form.Value
+	Func func(text string) string
+}
+
+func (v Transform) From(text string) error {
+	return v.Value.From(v.rewrite(text))
+}
+
+func (v Transform) rewrite(text string) string {
+	return v.Func(text)
+}
+
+func (v Transform) Unwrap() form.Value {
+	return v.Value
+}
+
+// Default substitutes text for empty input, such that leaving a field empty picks the default.
+type Default struct {
+	form.Value
+	Text string
+}
+
+func (v Default) From(text string) error {
+	return v.Value.From(v.rewrite(text))
+}
+
+func (v Default) rewrite(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return v.Text
+	}
+	return text
+}
+
+func (v Default) Unwrap() form.Value {
+	return v.Value
+}
+
+// Map stores a value of type T into a model of type U, for models whose type has no value of
+// its own, such as an `Int` stored as an int64:
+//
+//	value.Map[int, int64]{
+//		Value: &id,
+//		Of:    func(n *int) form.Value { return value.Int{Value: n} },
+//		Store: func(n int) (int64, error) { return int64(n), nil },
+//		Load:  func(n int64) int { return int(n) },
+//	}
+type Map[T, U any] struct {
+	Value *U
+	// Of creates the value that maps text to T.
+	Of func(*T) form.Value
+	// Store converts T into the model.
+	Store func(T) (U, error)
+	// Load converts the model into T.
+	Load func(U) T
+}
+
+func (v Map[T, U]) To() (string, error) {
+	t := v.Load(*v.Value)
+	return v.Of(&t).To()
+}
+
+func (v Map[T, U]) From(text string) error {
+	t := v.Load(*v.Value)
+	if err := v.Of(&t).From(text); err != nil {
+		return err
+	}
+	u, err := v.Store(t)
+	if err != nil {
+		return err
+	}
+	*v.Value = u
+	return nil
+}
+
+func (v Map[T, U]) Clear() {
+	var t T
+	v.Of(&t).Clear()
+	if u, err := v.Store(t); err == nil {
+		*v.Value = u
+	}
+}
+
+func (v Map[T, U]) Required() bool {
+	var t T
+	return form.IsRequired(v.Of(&t))
+}
+
+func (v Map[T, U]) Schema(s form.Schema) {
+	var t T
+	form.Describe(v.Of(&t), s)
+}
@@ -0,0 +1,150 @@
+package value
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+func TestFunc(t *testing.T) {
+	var stored string
+	v := Func{
+		Format: func() (string, error) { return strings.ToUpper(stored), nil },
+		Parse:  func(text string) error { stored = text; return nil },
+		Reset:  func() { stored = "" },
+	}
+	if err := v.From("abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "ABC" {
+		t.Fatalf("want %q, got %q", "ABC", text)
+	}
+	v.Clear()
+	if stored != "" {
+		t.Fatalf("want cleared, got %q", stored)
+	}
+	if err := (Func{}).From("x"); err != nil {
+		t.Fatalf("want nil functions to do nothing, got %v", err)
+	}
+}
+
+func TestAll(t *testing.T) {
+	var name string
+	reserved := Check(func(text string) error {
+		if strings.EqualFold(text, "admin") {
+			return fmt.Errorf("is reserved")
+		}
+		return nil
+	})
+	v := All{Required{Value: Text{Value: &name}}, reserved}
+	if err := v.From("Admin"); errText(err) != "is reserved" {
+		t.Fatalf("want %q, got %v", "is reserved", err)
+	}
+	if err := v.From(""); errText(err) != "required" {
+		t.Fatalf("want %q, got %v", "required", err)
+	}
+	if err := v.From("jack"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "jack" {
+		t.Fatalf("want %q, got %q", "jack", text)
+	}
+	if err := (All{Text{Value: &name}, reserved}).From("admin"); err == nil || name != "jack" {
+		t.Fatalf("want rejected text left unstored, got %q (%v)", name, err)
+	}
+	if !form.IsRequired(v) {
+		t.Fatalf("want required")
+	}
+}
+
+func TestAny(t *testing.T) {
+	var (
+		d    time.Duration
+		v    = Any{Duration{Value: &d}, Days{Value: &d}}
+		want = 36 * time.Hour
+	)
+	if err := v.From("36h"); err != nil || d != want {
+		t.Fatalf("want %v, got %v (%v)", want, d, err)
+	}
+	if err := v.From("3"); err != nil || d != 72*time.Hour {
+		t.Fatalf("want %v, got %v (%v)", 72*time.Hour, d, err)
+	}
+	if err := v.From("soon"); err == nil {
+		t.Fatalf("want error, got nil")
+	}
+	if text, _ := v.To(); text != "72h" {
+		t.Fatalf("want first value to format, got %q", text)
+	}
+	schema := form.Schema{}
+	form.Describe(v, schema)
+	if options, _ := schema["anyOf"].([]any); len(options) != 2 {
+		t.Fatalf("want anyOf of 2, got %v", schema)
+	}
+}
+
+func TestNot(t *testing.T) {
+	var name string
+	email := func(text string) error {
+		_, err := parse.Email(text)
+		return err
+	}
+	v := All{Text{Value: &name}, Not{Check: email, Message: "must not be an email address"}}
+	if err := v.From("jack@example.com"); errText(err) != "must not be an email address" || name != "" {
+		t.Fatalf("want rejected without storing, got %q (%v)", name, err)
+	}
+	if err := v.From("jack"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTransformDefault(t *testing.T) {
+	var n int
+	v := Default{Value: Transform{Value: Int{Value: &n}, Func: func(text string) string {
+		return strings.TrimSuffix(text, "%")
+	}}, Text: "50"}
+	if err := v.From("25%"); err != nil || n != 25 {
+		t.Fatalf("want 25, got %d (%v)", n, err)
+	}
+	if err := v.From(" "); err != nil || n != 50 {
+		t.Fatalf("want default 50, got %d (%v)", n, err)
+	}
+}
+
+func TestMap(t *testing.T) {
+	var (
+		id int64 = 7
+		v        = Map[int, int64]{
+			Value: &id,
+			Of:    func(n *int) form.Value { return Required{Value: Int{Value: n}} },
+			Store: func(n int) (int64, error) {
+				if n > math.MaxInt32 {
+					return 0, fmt.Errorf("too large")
+				}
+				return int64(n), nil
+			},
+			Load: func(n int64) int { return int(n) },
+		}
+	)
+	if text, _ := v.To(); text != "7" {
+		t.Fatalf("want %q, got %q", "7", text)
+	}
+	if err := v.From("42"); err != nil || id != 42 {
+		t.Fatalf("want 42, got %d (%v)", id, err)
+	}
+	if err := v.From("99999999999"); errText(err) != "too large" || id != 42 {
+		t.Fatalf("want model untouched on error, got %d (%v)", id, err)
+	}
+	if !form.IsRequired(v) {
+		t.Fatalf("want required")
+	}
+	schema := form.Schema{}
+	form.Describe(v, schema)
+	if schema["type"] != "integer" {
+		t.Fatalf("want integer schema, got %v", schema)
+	}
+}
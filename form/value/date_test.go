@@ -0,0 +1,51 @@
+package value
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+func TestDateRelative(t *testing.T) {
+	var (
+		date time.Time
+		v    = Date{
+			Value:    &date,
+			Relative: true,
+			Now:      func() time.Time { return time.Date(2021, 3, 31, 12, 0, 0, 0, time.Local) },
+		}
+	)
+	if err := v.From("tomorrow"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "1/4/2021" {
+		t.Fatalf("want %q, got %q", "1/4/2021", text)
+	}
+	if err := v.From("31/2/2021"); err == nil || err.Error() != "day must be between 1 and 28 for February 2021: 31" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDateSchema(t *testing.T) {
+	var (
+		d       time.Time
+		initial = time.Date(2021, 3, 1, 0, 0, 0, 0, time.Local)
+	)
+	s := form.Schema{}
+	form.Describe(Date{Value: &d, Default: initial}, s)
+	if _, ok := s["format"]; ok || s["default"] != "1/3/2021" {
+		t.Fatalf("want default as displayed and no date format, got %v", s)
+	}
+	s = form.Schema{}
+	form.Describe(Date{Value: &d, Default: initial, Layouts: []string{parse.ISO}}, s)
+	if s["format"] != "date" || s["default"] != "2021-03-01" {
+		t.Fatalf("want ISO date format, got %v", s)
+	}
+	s = form.Schema{}
+	form.Describe(Date{Value: &d, Layouts: []string{parse.ISO}, Relative: true}, s)
+	if _, ok := s["format"]; ok {
+		t.Fatalf("want no date format for relative dates, got %v", s)
+	}
+}
@@ -0,0 +1,233 @@
+package value
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// DateRange maps text to a span of dates, such as "1/3/2021 - 15/3/2021", keeping the start on
+// or before the end.
+//
+// To bind a pair of inputs instead of one, use the values returned by StartValue and EndValue,
+// which validate against the text of each other's input.
+type DateRange struct {
+	Start, End *time.Time
+	// Layouts accepted for each date, in order of preference. Defaults to `parse.DMY`.
+	Layouts []string
+	// Display is the layout used to display each date. Defaults to the first layout.
+	Display string
+	// MinDays and MaxDays bound the days from start to end, such that a range that starts and
+	// ends on the same day spans zero days. Zero is unbounded.
+	MinDays, MaxDays int
+	// Earliest and Latest bound the window that the range must fall within. Zero is unbounded.
+	Earliest, Latest time.Time
+	// NoPast rejects ranges that start before today.
+	NoPast bool
+	// BusinessDays rejects ranges that start or end on a weekend.
+	BusinessDays bool
+	// Now is the clock that NoPast is evaluated against. Defaults to `time.Now`.
+	Now func() time.Time
+}
+
+func (v DateRange) To() (string, error) {
+	if v.Start.IsZero() && v.End.IsZero() {
+		return "", nil
+	}
+	return v.format(*v.Start) + " - " + v.format(*v.End), nil
+}
+
+func (v DateRange) From(text string) error {
+	start, end, err := v.split(text)
+	if err != nil {
+		return err
+	}
+	if err := v.check(start, end); err != nil {
+		return err
+	}
+	*v.Start, *v.End = start, end
+	return nil
+}
+
+func (v DateRange) Clear() {
+	*v.Start, *v.End = time.Time{}, time.Time{}
+}
+
+func (v DateRange) Schema(s form.Schema) {
+	// No JSON Schema format describes the text of a range.
+	s["type"] = "string"
+}
+
+// StartValue maps a separate input to the start of the range, checked against the date in the
+// end input.
+func (v DateRange) StartValue(end form.Input) form.Value {
+	return rangeEnd{DateRange: v, start: true, other: end}
+}
+
+// EndValue maps a separate input to the end of the range, checked against the date in the start
+// input.
+func (v DateRange) EndValue(start form.Input) form.Value {
+	return rangeEnd{DateRange: v, other: start}
+}
+
+// separators that may delimit the dates of a range, in order of preference.
+var separators = []string{" - ", "–", "—", " to ", "..", "-"}
+
+// split parses text containing two dates. Separators that also appear within dates, such as the
+// hyphen of "2021-03-01", are resolved by trying each occurrence.
+func (v DateRange) split(text string) (start, end time.Time, err error) {
+	parser := parse.DateParser{Layouts: v.Layouts}
+	for _, sep := range separators {
+		for offset := 0; ; {
+			ii := strings.Index(text[offset:], sep)
+			if ii < 0 {
+				break
+			}
+			ii += offset
+			offset = ii + len(sep)
+			start, serr := parser.Parse(text[:ii])
+			end, eerr := parser.Parse(text[ii+len(sep):])
+			switch {
+			case serr == nil && eerr == nil:
+				return start, end, nil
+			case sep != "-" && err == nil && serr != nil:
+				err = fmt.Errorf("start %w", serr)
+			case sep != "-" && err == nil && eerr != nil:
+				err = fmt.Errorf("end %w", eerr)
+			}
+		}
+	}
+	if err == nil {
+		hint := parse.Hint(v.display())
+		err = fmt.Errorf("must be %s - %s", hint, hint)
+	}
+	return start, end, err
+}
+
+// check the range against the constraints.
+func (v DateRange) check(start, end time.Time) error {
+	if end.Before(start) {
+		return fmt.Errorf("end must not be before start")
+	}
+	days := int(math.Round(end.Sub(start).Hours() / 24))
+	if v.MinDays > 0 && days < v.MinDays {
+		return fmt.Errorf("must span at least %s", plural(v.MinDays, "day"))
+	}
+	if v.MaxDays > 0 && days > v.MaxDays {
+		return fmt.Errorf("must span at most %s", plural(v.MaxDays, "day"))
+	}
+	if err := v.checkDate("start", start); err != nil {
+		return err
+	}
+	return v.checkDate("end", end)
+}
+
+// checkDate checks one end of the range against the window.
+func (v DateRange) checkDate(name string, t time.Time) error {
+	if v.NoPast {
+		now := clock(v.Now)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, t.Location())
+		if t.Before(today) {
+			return fmt.Errorf("%s must not be in the past", name)
+		}
+	}
+	if v.BusinessDays && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return fmt.Errorf("%s must be a business day", name)
+	}
+	if !v.Earliest.IsZero() && t.Before(v.Earliest) {
+		return fmt.Errorf("%s must not be before %s", name, v.format(v.Earliest))
+	}
+	if !v.Latest.IsZero() && t.After(v.Latest) {
+		return fmt.Errorf("%s must not be after %s", name, v.format(v.Latest))
+	}
+	return nil
+}
+
+func (v DateRange) format(t time.Time) string {
+	return parse.FormatDate(t, v.display())
+}
+
+func (v DateRange) display() string {
+	return Date{Layouts: v.Layouts, Display: v.Display}.display()
+}
+
+// rangeEnd maps an input to one end of a date range.
+//
+// The other end is read from the text of its input rather than the stored range, such that both
+// ends can move at once. When the pair is valid both ends are stored and the error of the other
+// input is cleared.
+type rangeEnd struct {
+	DateRange
+	start bool
+	other form.Input
+}
+
+func (v rangeEnd) To() (string, error) {
+	t := *v.End
+	if v.start {
+		t = *v.Start
+	}
+	if t.IsZero() {
+		return "", nil
+	}
+	return v.format(t), nil
+}
+
+func (v rangeEnd) From(text string) error {
+	parser := parse.DateParser{Layouts: v.Layouts}
+	t, err := parser.Parse(text)
+	if err != nil {
+		return err
+	}
+	other, err := parser.Parse(v.other.Text())
+	if err != nil {
+		// The other input reports its own error, so check this end alone.
+		name := "end"
+		if v.start {
+			name = "start"
+		}
+		if err := v.checkDate(name, t); err != nil {
+			return err
+		}
+		if v.start {
+			*v.Start = t
+		} else {
+			*v.End = t
+		}
+		return nil
+	}
+	start, end := t, other
+	if !v.start {
+		start, end = other, t
+	}
+	if err := v.check(start, end); err != nil {
+		return err
+	}
+	*v.Start, *v.End = start, end
+	v.other.ClearError()
+	return nil
+}
+
+func (v rangeEnd) Clear() {
+	if v.start {
+		*v.Start = time.Time{}
+	} else {
+		*v.End = time.Time{}
+	}
+}
+
+func (v rangeEnd) Schema(s form.Schema) {
+	Date{Layouts: v.Layouts, Display: v.Display}.Schema(s)
+}
+
+// plural formats a count of a noun, such as "1 day" or "2 days".
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
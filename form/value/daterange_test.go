@@ -0,0 +1,194 @@
+package value
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+func TestDateRange(t *testing.T) {
+	var (
+		start, end time.Time
+		now        = func() time.Time { return time.Date(2021, 3, 3, 12, 0, 0, 0, time.Local) }
+	)
+	for _, tt := range []struct {
+		Label string
+		Range DateRange
+		Text  string
+		Start time.Time
+		End   time.Time
+		Err   string
+	}{
+		{
+			Label: "single text",
+			Range: DateRange{},
+			Text:  "1/3/2021 - 15/3/2021",
+			Start: date(2021, 3, 1),
+			End:   date(2021, 3, 15),
+		},
+		{
+			Label: "unspaced hyphen",
+			Range: DateRange{},
+			Text:  "1/3/2021-15/3/2021",
+			Start: date(2021, 3, 1),
+			End:   date(2021, 3, 15),
+		},
+		{
+			Label: "iso with hyphens",
+			Range: DateRange{Layouts: []string{"yyyy-mm-dd"}},
+			Text:  "2021-03-01-2021-03-15",
+			Start: date(2021, 3, 1),
+			End:   date(2021, 3, 15),
+		},
+		{
+			Label: "to",
+			Range: DateRange{},
+			Text:  "1/3/2021 to 1/3/2021",
+			Start: date(2021, 3, 1),
+			End:   date(2021, 3, 1),
+		},
+		{
+			Label: "reversed",
+			Range: DateRange{},
+			Text:  "15/3/2021 - 1/3/2021",
+			Err:   "end must not be before start",
+		},
+		{
+			Label: "bad start",
+			Range: DateRange{},
+			Text:  "30/2/2021 - 15/3/2021",
+			Err:   "start day must be between 1 and 28 for February 2021: 30",
+		},
+		{
+			Label: "no separator",
+			Range: DateRange{},
+			Text:  "1/3/2021",
+			Err:   "must be dd/mm/yyyy - dd/mm/yyyy",
+		},
+		{
+			Label: "min span",
+			Range: DateRange{MinDays: 2},
+			Text:  "1/3/2021 - 2/3/2021",
+			Err:   "must span at least 2 days",
+		},
+		{
+			Label: "max span",
+			Range: DateRange{MaxDays: 7},
+			Text:  "1/3/2021 - 15/3/2021",
+			Err:   "must span at most 7 days",
+		},
+		{
+			Label: "past",
+			Range: DateRange{NoPast: true, Now: now},
+			Text:  "2/3/2021 - 5/3/2021",
+			Err:   "start must not be in the past",
+		},
+		{
+			Label: "weekend",
+			Range: DateRange{BusinessDays: true},
+			Text:  "1/3/2021 - 6/3/2021",
+			Err:   "end must be a business day",
+		},
+		{
+			Label: "window",
+			Range: DateRange{Latest: date(2021, 3, 31)},
+			Text:  "1/3/2021 - 1/4/2021",
+			Err:   "end must not be after 31/3/2021",
+		},
+	} {
+		t.Run(tt.Label, func(t *testing.T) {
+			start, end = time.Time{}, time.Time{}
+			v := tt.Range
+			v.Start, v.End = &start, &end
+			err := v.From(tt.Text)
+			if tt.Err != "" {
+				if err == nil || err.Error() != tt.Err {
+					t.Fatalf("want error %q, got %v", tt.Err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !start.Equal(tt.Start) || !end.Equal(tt.End) {
+				t.Fatalf("want %v - %v, got %v - %v", tt.Start, tt.End, start, end)
+			}
+		})
+	}
+}
+
+// input is a minimal `form.Input`.
+type input struct {
+	text, err string
+}
+
+func (in *input) Text() string        { return in.text }
+func (in *input) SetText(text string) { in.text = text }
+func (in *input) SetError(err string) { in.err = err }
+func (in *input) ClearError()         { in.err = "" }
+
+func TestDateRangeInputs(t *testing.T) {
+	var (
+		start, end time.Time
+		v          = DateRange{Start: &start, End: &end}
+		from, to   input
+		f          form.Form
+	)
+	f.Load([]form.Field{
+		{Value: v.StartValue(&to), Input: &from},
+		{Value: v.EndValue(&from), Input: &to},
+	})
+	from.text, to.text = "1/3/2021", "5/3/2021"
+	if !f.Submit() {
+		t.Fatalf("unexpected errors: %q, %q", from.err, to.err)
+	}
+	// Move both ends past the stored end at once.
+	from.text, to.text = "10/3/2021", "15/3/2021"
+	if !f.Submit() || !start.Equal(date(2021, 3, 10)) || !end.Equal(date(2021, 3, 15)) {
+		t.Fatalf("want both ends moved, got %v - %v (%q, %q)", start, end, from.err, to.err)
+	}
+	// An end before the other input's start is rejected without storing.
+	from.text = "20/3/2021"
+	f.Validate()
+	if from.err != "end must not be before start" || !start.Equal(date(2021, 3, 10)) {
+		t.Fatalf("want start rejected and untouched, got %q, %v", from.err, start)
+	}
+	// Moving the other end to fit clears the error of both.
+	to.text = "25/3/2021"
+	f.Validate()
+	if from.err != "" || to.err != "" || !start.Equal(date(2021, 3, 20)) || !end.Equal(date(2021, 3, 25)) {
+		t.Fatalf("want both ends stored, got %v - %v (%q, %q)", start, end, from.err, to.err)
+	}
+	if text, _ := v.To(); text != "20/3/2021 - 25/3/2021" {
+		t.Fatalf("want %q, got %q", "20/3/2021 - 25/3/2021", text)
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestDateRangeSchema(t *testing.T) {
+	var start, end time.Time
+	for _, tt := range []struct {
+		Range  DateRange
+		Format any
+	}{
+		{Range: DateRange{Start: &start, End: &end}},
+		{Range: DateRange{Start: &start, End: &end, Layouts: []string{parse.ISO}}, Format: "date"},
+	} {
+		s := form.Schema{}
+		form.Describe(tt.Range, s)
+		if !reflect.DeepEqual(s, form.Schema{"type": "string"}) {
+			t.Fatalf("want range described as text, got %v", s)
+		}
+		s = form.Schema{}
+		form.Describe(tt.Range.EndValue(&input{}), s)
+		if got := s["format"]; got != tt.Format {
+			t.Fatalf("want end format %v, got %v", tt.Format, got)
+		}
+	}
+}
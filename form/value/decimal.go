@@ -0,0 +1,119 @@
+package value
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/money"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// Decimal maps text to an exact decimal number, avoiding the rounding errors of binary floating
+// point.
+type Decimal struct {
+	Value *big.Rat
+	// Places is the number of decimal places displayed and accepted, such as 2 for cents.
+	// Negative accepts any number of places and displays as many as the number needs.
+	Places int
+	// Locale determines the separators and signs, defaulting to `parse.DefaultLocale`.
+	Locale *parse.Locale
+	// Grouped separates groups of thousands.
+	Grouped bool
+}
+
+func (v Decimal) To() (string, error) {
+	return locale(v.Locale).FormatDecimal(v.Value, v.Places, v.Grouped), nil
+}
+
+func (v Decimal) From(text string) error {
+	r, err := locale(v.Locale).ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+	if v.Places >= 0 && parse.Places(r) > v.Places {
+		return BoundError{Bound: Decimals, Limit: float64(v.Places)}
+	}
+	v.Value.Set(r)
+	return nil
+}
+
+func (v Decimal) Clear() {
+	v.Value.SetInt64(0)
+}
+
+func (v Decimal) Number() float64 {
+	n, _ := v.Value.Float64()
+	return n
+}
+
+func (v Decimal) ParseNumber(text string) (float64, error) {
+	r, err := locale(v.Locale).ParseDecimal(text)
+	if err != nil {
+		return 0, err
+	}
+	n, _ := r.Float64()
+	return n, nil
+}
+
+func (v Decimal) Schema(s form.Schema) {
+	s["type"] = "number"
+	if v.Places >= 0 {
+		s["multipleOf"] = math.Pow10(-v.Places)
+	}
+}
+
+// Money maps text to an exact amount of money.
+//
+// Text may name the currency by symbol or code, such as "$12.50" or "12.50 USD". If Currency is
+// set, it is assumed when text doesn't name one and other currencies are rejected.
+type Money struct {
+	Value *money.Amount
+	// Currency is the ISO 4217 code of the accepted currency.
+	// Empty accepts any currency, which the text must name.
+	Currency string
+	// Locale determines the separators, signs and symbol placement, defaulting to
+	// `parse.DefaultLocale`.
+	Locale *parse.Locale
+}
+
+func (v Money) To() (string, error) {
+	amount := *v.Value
+	if amount.Currency == "" {
+		amount.Currency = v.Currency
+	}
+	if amount.Currency == "" {
+		return "", nil
+	}
+	return amount.Format(locale(v.Locale)), nil
+}
+
+func (v Money) From(text string) error {
+	amount, err := money.Parse(text, locale(v.Locale), v.Currency)
+	if err != nil {
+		return err
+	}
+	if v.Currency != "" && amount.Currency != v.Currency {
+		return fmt.Errorf("must be in %s", v.Currency)
+	}
+	*v.Value = amount
+	return nil
+}
+
+func (v Money) Clear() {
+	*v.Value = money.Amount{Currency: v.Currency}
+}
+
+func (v Money) Schema(s form.Schema) {
+	currency := form.Schema{"type": "string", "pattern": "^[A-Z]{3}$"}
+	if v.Currency != "" {
+		currency = form.Schema{"const": v.Currency}
+	}
+	s["type"] = "object"
+	s["properties"] = form.Schema{
+		"minor":    form.Schema{"type": "integer"},
+		"currency": currency,
+	}
+	s["required"] = []string{"minor", "currency"}
+}
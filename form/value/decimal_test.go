@@ -0,0 +1,55 @@
+package value
+
+import (
+	"math/big"
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form/money"
+)
+
+func TestDecimal(t *testing.T) {
+	var (
+		total = new(big.Rat)
+		price = new(big.Rat)
+		v     = Decimal{Value: price, Places: 2}
+	)
+	for range [3]struct{}{} {
+		if err := v.From("0.10"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		total.Add(total, price)
+	}
+	if want := big.NewRat(3, 10); total.Cmp(want) != 0 {
+		t.Fatalf("want %v, got %v", want, total)
+	}
+	if err := v.From("0.105"); err == nil {
+		t.Fatalf("want error for extra decimal places")
+	}
+	for _, text := range []string{"1e-1000", "1e-160000"} {
+		if err := v.From(text); err == nil {
+			t.Fatalf("%q: want error for extra decimal places", text)
+		}
+	}
+	if text, _ := (Decimal{Value: total, Places: -1}).To(); text != "0.3" {
+		t.Fatalf("want %q, got %q", "0.3", text)
+	}
+}
+
+func TestMoney(t *testing.T) {
+	var (
+		amount money.Amount
+		v      = Money{Value: &amount, Currency: "AUD"}
+	)
+	if text, _ := v.To(); text != "A$0.00" {
+		t.Fatalf("want %q, got %q", "A$0.00", text)
+	}
+	if err := v.From("19.99"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (money.Amount{Minor: 1999, Currency: "AUD"}); amount != want {
+		t.Fatalf("want %v, got %v", want, amount)
+	}
+	if err := v.From("€5"); err == nil || err.Error() != "must be in AUD" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package value
+
+import (
+	"fmt"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// Duration maps text to a length of time, such as "1h30m", "90 minutes" or "2 weeks 3 days".
+//
+// `Days` is the special case of whole days written as a bare number.
+type Duration struct {
+	Value *time.Duration
+	// Units used for display, such as hours and minutes. See `parse.FormatDuration`.
+	Units []time.Duration
+	// Long spells out the units for display, such as "1 hour 30 minutes".
+	Long bool
+	// Bare is the unit of a number written without one. Zero rejects bare numbers.
+	Bare time.Duration
+	// Min and Max bound the duration, where zero is unbounded.
+	Min, Max time.Duration
+}
+
+func (v Duration) To() (string, error) {
+	return parse.FormatDuration(*v.Value, v.Units, v.Long), nil
+}
+
+func (v Duration) From(text string) error {
+	d, err := parse.DurationIn(text, v.Bare)
+	if err != nil {
+		return err
+	}
+	if v.Min != 0 && d < v.Min {
+		return fmt.Errorf("must be at least %s", parse.FormatDuration(v.Min, v.Units, v.Long))
+	}
+	if v.Max != 0 && d > v.Max {
+		return fmt.Errorf("must be at most %s", parse.FormatDuration(v.Max, v.Units, v.Long))
+	}
+	*v.Value = d
+	return nil
+}
+
+func (v Duration) Clear() {
+	*v.Value = 0
+}
+
+func (v Duration) Schema(s form.Schema) {
+	s["type"] = "string"
+}
@@ -0,0 +1,72 @@
+package value
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	var (
+		d time.Duration
+		v = Duration{Value: &d, Min: 15 * time.Minute, Max: 8 * time.Hour}
+	)
+	if err := v.From("1.5 hours"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "1h30m" {
+		t.Fatalf("want %q, got %q", "1h30m", text)
+	}
+	if err := v.From("10m"); err == nil || err.Error() != "must be at least 15m" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := v.From("1 day"); err == nil || err.Error() != "must be at most 8h" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v.Clear()
+	if d != 0 {
+		t.Fatalf("want zero after clear, got %v", d)
+	}
+}
+
+func TestDays(t *testing.T) {
+	var (
+		d time.Duration
+		v = Days{Value: &d}
+	)
+	for _, tt := range []struct {
+		Text string
+		Want time.Duration
+		Show string
+	}{
+		{Text: "3", Want: 72 * time.Hour, Show: "3"},
+		{Text: "2 weeks", Want: 14 * 24 * time.Hour, Show: "14"},
+	} {
+		if err := v.From(tt.Text); err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.Text, err)
+		}
+		if d != tt.Want {
+			t.Fatalf("%q: want %v, got %v", tt.Text, tt.Want, d)
+		}
+		if text, _ := v.To(); text != tt.Show {
+			t.Fatalf("%q: want %q, got %q", tt.Text, tt.Show, text)
+		}
+	}
+	for text, want := range map[string]string{
+		"0":    "must be at least 1 day",
+		"1.5":  `must be a whole number of days, such as "3"`,
+		"25h":  `must be a whole number of days, such as "3"`,
+		"":     `must be a whole number of days, such as "3"`,
+		"soon": `must be a whole number of days, such as "3"`,
+	} {
+		if err := v.From(text); err == nil || err.Error() != want {
+			t.Fatalf("%q: want %q, got %v", text, want, err)
+		}
+		if d != 14*24*time.Hour {
+			t.Fatalf("%q: want model untouched, got %v", text, d)
+		}
+	}
+	v.Clear()
+	if text, _ := v.To(); d != 0 || text != "" {
+		t.Fatalf("want zero after clear, got %v (%q)", d, text)
+	}
+}
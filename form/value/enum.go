@@ -0,0 +1,116 @@
+package value
+
+import (
+	"fmt"
+	"strings"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// Option is a choice offered by an `Enum`.
+type Option[T any] struct {
+	Value T
+	// Label describes the option to a person, such as "Australia".
+	Label string
+	// Key is an alternative name accepted in text, such as "AU".
+	Key string
+	// Disabled options are displayed but can't be chosen.
+	Disabled bool
+}
+
+// Enum maps text to one of a fixed set of options, such as a status or a country.
+// Text names an option by label or key, ignoring case, and is formatted as the label.
+// Empty text stores the zero value, so wrap with `Required` to demand a choice.
+type Enum[T comparable] struct {
+	Value   *T
+	Options []Option[T]
+	// Source provides options that change at runtime, in place of Options.
+	Source func() []Option[T]
+}
+
+// Choices lists the options, for select widgets to render.
+func (v Enum[T]) Choices() []Option[T] {
+	if v.Source != nil {
+		return v.Source()
+	}
+	return v.Options
+}
+
+func (v Enum[T]) To() (string, error) {
+	var zero T
+	for _, option := range v.Choices() {
+		if option.Value == *v.Value {
+			return option.Label, nil
+		}
+	}
+	if *v.Value == zero {
+		return "", nil
+	}
+	return "", fmt.Errorf("%v is not an option", *v.Value)
+}
+
+func (v Enum[T]) From(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		var zero T
+		*v.Value = zero
+		return nil
+	}
+	var labels []string
+	for _, option := range v.Choices() {
+		if strings.EqualFold(text, option.Label) || option.Key != "" && strings.EqualFold(text, option.Key) {
+			if option.Disabled {
+				return fmt.Errorf("%s is not available", option.Label)
+			}
+			*v.Value = option.Value
+			return nil
+		}
+		if !option.Disabled {
+			labels = append(labels, option.Label)
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(labels, ", "))
+}
+
+// Get returns the key of the chosen option, or its label if it has no key, for widgets that
+// identify options by string.
+func (v Enum[T]) Get() (any, error) {
+	for _, option := range v.Choices() {
+		if option.Value == *v.Value {
+			if option.Key != "" {
+				return option.Key, nil
+			}
+			return option.Label, nil
+		}
+	}
+	return "", nil
+}
+
+func (v Enum[T]) Set(data any) error {
+	text, ok := data.(string)
+	if !ok {
+		return fmt.Errorf("want string, got %T", data)
+	}
+	return v.From(text)
+}
+
+func (v Enum[T]) Clear() {
+	var zero T
+	*v.Value = zero
+}
+
+func (v Enum[T]) Schema(s form.Schema) {
+	// Text names options by label or key, whatever the type of their values.
+	var names []any
+	for _, option := range v.Choices() {
+		if option.Disabled {
+			continue
+		}
+		names = append(names, option.Label)
+		if option.Key != "" && option.Key != option.Label {
+			names = append(names, option.Key)
+		}
+	}
+	s["type"] = "string"
+	s["enum"] = names
+}
@@ -0,0 +1,89 @@
+package value
+
+import (
+	"reflect"
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+type priority int
+
+const (
+	low priority = iota + 1
+	medium
+	high
+)
+
+func TestEnum(t *testing.T) {
+	var (
+		p priority
+		v = Enum[priority]{Value: &p, Options: []Option[priority]{
+			{Value: low, Label: "Low", Key: "l"},
+			{Value: medium, Label: "Medium", Key: "m"},
+			{Value: high, Label: "High", Key: "h", Disabled: true},
+		}}
+	)
+	for text, want := range map[string]priority{
+		"low":      low,
+		" MEDIUM ": medium,
+		"L":        low,
+		"":         0,
+	} {
+		if err := v.From(text); err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if p != want {
+			t.Fatalf("%q: want %v, got %v", text, want, p)
+		}
+	}
+	p = medium
+	for text, want := range map[string]string{
+		"high":   "High is not available",
+		"urgent": "must be one of Low, Medium",
+	} {
+		if err := v.From(text); errText(err) != want {
+			t.Fatalf("%q: want %q, got %v", text, want, err)
+		}
+	}
+	if p != medium {
+		t.Fatalf("want model untouched on error, got %v", p)
+	}
+	if text, _ := v.To(); text != "Medium" {
+		t.Fatalf("want %q, got %q", "Medium", text)
+	}
+	p = 9
+	if _, err := v.To(); errText(err) != "9 is not an option" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := form.Schema{}
+	form.Describe(v, s)
+	want := form.Schema{"type": "string", "enum": []any{"Low", "l", "Medium", "m"}}
+	if !reflect.DeepEqual(s, want) {
+		t.Fatalf("want %v, got %v", want, s)
+	}
+	// Every name the schema lists is accepted.
+	for _, name := range s["enum"].([]any) {
+		if err := v.From(name.(string)); err != nil {
+			t.Fatalf("%q: unexpected error: %v", name, err)
+		}
+	}
+}
+
+func TestEnumSource(t *testing.T) {
+	var (
+		country   string
+		countries = []Option[string]{{Value: "AU", Label: "Australia"}}
+		v         = Enum[string]{Value: &country, Source: func() []Option[string] { return countries }}
+	)
+	if err := v.From("new zealand"); err == nil {
+		t.Fatalf("want error, got nil")
+	}
+	countries = append(countries, Option[string]{Value: "NZ", Label: "New Zealand"})
+	if err := v.From("new zealand"); err != nil || country != "NZ" {
+		t.Fatalf("want %q, got %q (%v)", "NZ", country, err)
+	}
+	if n := len(v.Choices()); n != 2 {
+		t.Fatalf("want 2 choices, got %d", n)
+	}
+}
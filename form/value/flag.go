@@ -0,0 +1,43 @@
+package value
+
+import (
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// Flag adapts a `form.Value` to `flag.Value`, `encoding.TextMarshaler` and
+// `encoding.TextUnmarshaler`, so that command line flags and config files share parsing and error
+// messages with forms.
+//
+//	var age int
+//	flags.Var(value.Flag{value.Int{Value: &age, Default: 18}}, "age", "age in years")
+type Flag struct {
+	form.Value
+}
+
+// String implements `flag.Value`.
+func (f Flag) String() string {
+	if f.Value == nil {
+		return ""
+	}
+	text, err := f.To()
+	if err != nil {
+		return ""
+	}
+	return text
+}
+
+// Set implements `flag.Value`.
+func (f Flag) Set(text string) error {
+	return f.From(text)
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (f Flag) MarshalText() ([]byte, error) {
+	text, err := f.To()
+	return []byte(text), err
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (f Flag) UnmarshalText(text []byte) error {
+	return f.From(string(text))
+}
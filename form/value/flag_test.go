@@ -0,0 +1,53 @@
+package value
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestFlagSet(t *testing.T) {
+	var (
+		age   int
+		name  string
+		date  time.Time
+		flags = flag.NewFlagSet("test", flag.ContinueOnError)
+	)
+	flags.SetOutput(io.Discard)
+	flags.Var(Flag{Int{Value: &age, Default: 18}}, "age", "")
+	flags.Var(Flag{Required{Text{Value: &name}}}, "name", "")
+	flags.Var(Flag{Date{Value: &date}}, "date", "")
+	if err := flags.Parse([]string{"-age", "42", "-name", "Jack", "-date", "2/1/2021"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if age != 42 || name != "Jack" || date.Day() != 2 || date.Month() != time.January {
+		t.Fatalf("unexpected values: %d %q %v", age, name, date)
+	}
+	err := flags.Parse([]string{"-name", " "})
+	if err == nil || err.Error() != `invalid value " " for flag -name: required` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFlagText(t *testing.T) {
+	var config struct {
+		Retention Flag
+	}
+	var retention time.Duration
+	config.Retention = Flag{Days{Value: &retention}}
+	if err := json.Unmarshal([]byte(`{"Retention": "3"}`), &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if retention != 3*24*time.Hour {
+		t.Fatalf("want 3 days, got %v", retention)
+	}
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"Retention":"3"}`; string(b) != want {
+		t.Fatalf("want %s, got %s", want, b)
+	}
+}
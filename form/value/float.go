@@ -0,0 +1,105 @@
+package value
+
+import (
+	"strings"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// FloatFormat describes the textual representation of a floating point number.
+//
+// Parsed numbers are rounded to a chosen representation, such that the stored value is exactly
+// what is displayed and `To` followed by `From` reproduces it.
+//
+// The zero value displays at least two decimal places, and more where the number needs them, such
+// that "12.5" displays as "12.50" and "1.239" stores and displays 1.239 without rounding. Choose
+// `Fixed(2)` to round to 1.24.
+type FloatFormat struct {
+	verb    byte
+	prec    int
+	group   bool
+	percent bool
+}
+
+// Fixed displays a fixed number of decimal places.
+func Fixed(places int) FloatFormat {
+	return FloatFormat{verb: 'f', prec: places}
+}
+
+// Shortest displays the fewest digits that reproduce the number exactly, using an exponent for
+// very large and very small magnitudes. Parsed numbers are stored without rounding.
+func Shortest() FloatFormat {
+	return FloatFormat{verb: 'g', prec: -1}
+}
+
+// Scientific displays an exponent with a fixed number of decimal places in the mantissa.
+func Scientific(places int) FloatFormat {
+	return FloatFormat{verb: 'e', prec: places}
+}
+
+// Grouped separates groups of thousands. Grouping separators are accepted when parsing regardless.
+func (f FloatFormat) Grouped() FloatFormat {
+	f.group = true
+	return f
+}
+
+// Percent displays the number as a percentage, such that 0.125 displays as "12.5%" with two
+// decimal places. Text without a percent sign is read as a percentage too.
+func (f FloatFormat) Percent() FloatFormat {
+	f.percent = true
+	return f
+}
+
+// spec returns the strconv verb and precision to display n, accounting for the zero value.
+func (f FloatFormat) spec(n float64) (byte, int) {
+	if f.verb == 0 {
+		places := decimals(n)
+		if f.percent {
+			places -= 2
+		}
+		if places > 2 {
+			return 'f', places
+		}
+		return 'f', 2
+	}
+	return f.verb, f.prec
+}
+
+func (f FloatFormat) format(l parse.Locale, n float64) string {
+	verb, prec := f.spec(n)
+	if f.percent {
+		return l.FormatFloat(n*100, verb, prec, f.group) + l.Percent
+	}
+	return l.FormatFloat(n, verb, prec, f.group)
+}
+
+func (f FloatFormat) parse(l parse.Locale, text string) (float64, error) {
+	n, err := f.read(l, text)
+	if err != nil {
+		return 0, err
+	}
+	if f.verb != 0 && f.prec >= 0 {
+		n, _ = f.read(l, f.format(l, n))
+	}
+	return n, nil
+}
+
+// read parses text without rounding.
+func (f FloatFormat) read(l parse.Locale, text string) (float64, error) {
+	n, err := l.ParseFloat(text)
+	if err != nil {
+		return 0, err
+	}
+	if f.percent && !strings.ContainsAny(text, "%‰") {
+		n /= 100
+	}
+	return n, nil
+}
+
+// locale dereferences an optional locale, falling back to the default.
+func locale(l *parse.Locale) parse.Locale {
+	if l == nil {
+		return parse.DefaultLocale
+	}
+	return *l
+}
@@ -0,0 +1,98 @@
+package value
+
+import (
+	"math"
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+func TestFloatFormat(t *testing.T) {
+	for _, tt := range []struct {
+		Label  string
+		Format FloatFormat
+		Text   string
+		Want   float64
+		Shown  string
+	}{
+		{"default stores without rounding", FloatFormat{}, "1.239", 1.239, "1.239"},
+		{"default keeps two places", FloatFormat{}, "12.5", 12.5, "12.50"},
+		{"default percent", FloatFormat{}.Percent(), "7%", 0.07, "7.00%"},
+		{"default percent places", FloatFormat{}.Percent(), "12.125%", 0.12125, "12.125%"},
+		{"fixed two", Fixed(2), "1.005", 1, "1.00"},
+		{"fixed", Fixed(4), "51.507222", 51.5072, "51.5072"},
+		{"fixed whole", Fixed(0), "2.5", 2, "2"},
+		{"shortest", Shortest(), "0.1", 0.1, "0.1"},
+		{"shortest scientific input", Shortest(), "6.02214076e23", 6.02214076e23, "6.02214076e+23"},
+		{"shortest tiny", Shortest(), "1.5e-7", 1.5e-7, "1.5e-07"},
+		{"scientific", Scientific(2), "123456", 123000, "1.23e+05"},
+		{"grouped", Fixed(2).Grouped(), "1,234,567.891", 1234567.89, "1,234,567.89"},
+		{"grouped negative", Shortest().Grouped(), "-1234", -1234, "-1,234"},
+		{"grouped accepts plain", Fixed(0).Grouped(), "1000", 1000, "1,000"},
+	} {
+		t.Run(tt.Label, func(t *testing.T) {
+			var (
+				n float64
+				v = Float{Value: &n, Format: tt.Format}
+			)
+			if err := v.From(tt.Text); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n != tt.Want {
+				t.Fatalf("want %v, got %v", tt.Want, n)
+			}
+			shown, _ := v.To()
+			if shown != tt.Shown {
+				t.Fatalf("want %q, got %q", tt.Shown, shown)
+			}
+			if err := v.From(shown); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n != tt.Want {
+				t.Fatalf("round trip: want %v, got %v", tt.Want, n)
+			}
+		})
+	}
+}
+
+func TestFloatShortestRoundTrip(t *testing.T) {
+	var n float64
+	for _, format := range []FloatFormat{Shortest(), {}} {
+		v := Float{Value: &n, Format: format}
+		for _, want := range []float64{0.1 + 0.2, math.Pi, math.MaxFloat64, math.SmallestNonzeroFloat64, -1e-300} {
+			n = want
+			text, _ := v.To()
+			if err := v.From(text); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n != want {
+				t.Fatalf("want %v, got %v via %q", want, n, text)
+			}
+		}
+	}
+}
+
+func TestFloatLocale(t *testing.T) {
+	de, _ := parse.LookupLocale("de")
+	var n float64
+	for _, tt := range []struct {
+		Value Float
+		Text  string
+		Want  float64
+		Shown string
+	}{
+		{Float{Value: &n, Locale: &de, Format: Fixed(2).Grouped()}, "1.234,567", 1234.57, "1.234,57"},
+		{Float{Value: &n, Locale: &de, Format: Fixed(1).Percent()}, "12,5", 0.125, "12,5\u00a0%"},
+		{Float{Value: &n, Format: Fixed(0).Percent()}, "50%", 0.5, "50%"},
+	} {
+		if err := tt.Value.From(tt.Text); err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.Text, err)
+		}
+		if n != tt.Want {
+			t.Fatalf("%q: want %v, got %v", tt.Text, tt.Want, n)
+		}
+		if shown, _ := tt.Value.To(); shown != tt.Shown {
+			t.Fatalf("want %q, got %q", tt.Shown, shown)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package value
+
+import (
+	"strings"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// Card maps text to the digits of a payment card number, displayed in groups.
+// See `parse.Card`.
+type Card struct {
+	Value *string
+	// Brands restricts the accepted brands. Any brand is accepted if empty.
+	Brands []parse.Brand
+}
+
+func (v Card) of() Of[string] {
+	return Of[string]{
+		Value: v.Value,
+		Parse: func(text string) (string, error) {
+			number, err := parse.Card(text)
+			if err != nil || len(v.Brands) == 0 {
+				return number, err
+			}
+			brand := parse.CardBrand(number)
+			var names []string
+			for _, b := range v.Brands {
+				if b == brand {
+					return number, nil
+				}
+				names = append(names, string(b))
+			}
+			return "", parse.Error{Code: parse.CodeBrand, Message: "must be " + strings.Join(names, " or ")}
+		},
+		Format: parse.FormatCard,
+	}
+}
+
+func (v Card) To() (string, error) {
+	return v.of().To()
+}
+
+func (v Card) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v Card) Clear() {
+	v.of().Clear()
+}
+
+func (v Card) Schema(s form.Schema) {
+	s["type"] = "string"
+	s["pattern"] = "^[0-9]{12,19}$"
+}
+
+// IBAN maps text to a compact international bank account number, displayed in groups of four.
+// See `parse.IBAN`.
+type IBAN struct {
+	Value *string
+}
+
+func (v IBAN) of() Of[string] {
+	return Of[string]{
+		Value:  v.Value,
+		Parse:  parse.IBAN,
+		Format: parse.FormatIBAN,
+	}
+}
+
+func (v IBAN) To() (string, error) {
+	return v.of().To()
+}
+
+func (v IBAN) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v IBAN) Clear() {
+	v.of().Clear()
+}
+
+func (v IBAN) Schema(s form.Schema) {
+	s["type"] = "string"
+	s["pattern"] = "^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$"
+}
+
+// ISBN maps text to the digits of an ISBN. See `parse.ISBN`.
+type ISBN struct {
+	Value *string
+	// Thirteen converts ISBN-10s to ISBN-13s.
+	Thirteen bool
+}
+
+func (v ISBN) of() Of[string] {
+	return Of[string]{
+		Value: v.Value,
+		Parse: func(text string) (string, error) {
+			isbn, err := parse.ISBN(text)
+			if err != nil || !v.Thirteen {
+				return isbn, err
+			}
+			return parse.ISBN13(isbn), nil
+		},
+		Format: func(isbn string) string { return isbn },
+	}
+}
+
+func (v ISBN) To() (string, error) {
+	return v.of().To()
+}
+
+func (v ISBN) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v ISBN) Clear() {
+	v.of().Clear()
+}
+
+func (v ISBN) Schema(s form.Schema) {
+	s["type"] = "string"
+	s["pattern"] = "^([0-9]{9}[0-9X]|97[89][0-9]{10})$"
+}
+
+// UUID maps text to a canonical UUID. See `parse.UUID`.
+type UUID struct {
+	Value *string
+}
+
+func (v UUID) of() Of[string] {
+	return Of[string]{
+		Value:  v.Value,
+		Parse:  parse.UUID,
+		Format: func(uuid string) string { return uuid },
+	}
+}
+
+func (v UUID) To() (string, error) {
+	return v.of().To()
+}
+
+func (v UUID) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v UUID) Clear() {
+	v.of().Clear()
+}
+
+func (v UUID) Schema(s form.Schema) {
+	s["type"] = "string"
+	s["format"] = "uuid"
+}
@@ -0,0 +1,58 @@
+package value
+
+import (
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+func TestCard(t *testing.T) {
+	var (
+		number string
+		v      = Card{Value: &number, Brands: []parse.Brand{parse.Visa, parse.Mastercard}}
+	)
+	if err := v.From("4111-1111-1111-1111"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if number != "4111111111111111" {
+		t.Fatalf("want digits stored, got %q", number)
+	}
+	if text, _ := v.To(); text != "4111 1111 1111 1111" {
+		t.Fatalf("want grouped text, got %q", text)
+	}
+	err := v.From("378282246310005")
+	if e, ok := err.(parse.Error); !ok || e.Code != parse.CodeBrand || e.Message != "must be Visa or Mastercard" {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+}
+
+func TestIBAN(t *testing.T) {
+	var iban string
+	v := IBAN{Value: &iban}
+	if err := v.From("gb82west12345698765432"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "GB82 WEST 1234 5698 7654 32" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+}
+
+func TestISBN(t *testing.T) {
+	var isbn string
+	if err := (ISBN{Value: &isbn, Thirteen: true}).From("0-306-40615-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if isbn != "9780306406157" {
+		t.Fatalf("want %q, got %q", "9780306406157", isbn)
+	}
+}
+
+func TestUUID(t *testing.T) {
+	var id string
+	if err := (UUID{Value: &id}).From("{123E4567E89B12D3A456426614174000}"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Fatalf("unexpected id: %q", id)
+	}
+}
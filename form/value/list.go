@@ -0,0 +1,186 @@
+package value
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// List maps delimited text to a list, each item mapped by its own value, such as tags or IDs:
+//
+//	value.List[int]{Value: &ids, Of: func(n *int) form.Value { return value.Int{Value: n} }}
+//
+// Items containing the separator or quotes are quoted with double quotes, doubling any quote
+// within, as in CSV. Empty items are skipped unless quoted.
+type List[T comparable] struct {
+	Value *[]T
+	// Of creates the value that maps the text of an item to T.
+	Of func(*T) form.Value
+	// Separator splits items, defaulting to a comma.
+	Separator string
+	// Join joins items, defaulting to the separator followed by a space, or the separator alone
+	// if it's whitespace.
+	Join string
+	// MinItems and MaxItems bound the number of items. Zero is unbounded.
+	MinItems, MaxItems int
+	// Unique rejects repeated items, for sets.
+	Unique bool
+}
+
+// ItemError reports an item that failed to parse or violates a list constraint.
+type ItemError struct {
+	// Item is the position of the item, starting at 1.
+	Item int
+	Err  error
+}
+
+func (err ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", err.Item, err.Err)
+}
+
+func (err ItemError) Unwrap() error {
+	return err.Err
+}
+
+func (v List[T]) separator() string {
+	if v.Separator == "" {
+		return ","
+	}
+	return v.Separator
+}
+
+func (v List[T]) join() string {
+	if v.Join != "" {
+		return v.Join
+	}
+	if sep := v.separator(); strings.TrimSpace(sep) != "" {
+		return sep + " "
+	}
+	return v.separator()
+}
+
+func (v List[T]) To() (string, error) {
+	var (
+		sep   = v.separator()
+		items = make([]string, 0, len(*v.Value))
+	)
+	for _, item := range *v.Value {
+		text, err := v.Of(&item).To()
+		if err != nil {
+			return "", err
+		}
+		if text == "" || strings.Contains(text, sep) || strings.Contains(text, `"`) ||
+			strings.TrimSpace(text) != text {
+			text = `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+		}
+		items = append(items, text)
+	}
+	return strings.Join(items, v.join()), nil
+}
+
+func (v List[T]) From(text string) error {
+	texts, err := splitItems(text, v.separator())
+	if err != nil {
+		return err
+	}
+	if len(texts) < v.MinItems {
+		return fmt.Errorf("must have at least %s", plural(v.MinItems, "item"))
+	}
+	if v.MaxItems > 0 && len(texts) > v.MaxItems {
+		return fmt.Errorf("must have at most %s", plural(v.MaxItems, "item"))
+	}
+	items := make([]T, len(texts))
+	for ii, text := range texts {
+		if err := v.Of(&items[ii]).From(text); err != nil {
+			return ItemError{Item: ii + 1, Err: err}
+		}
+		if v.Unique {
+			for jj := 0; jj < ii; jj++ {
+				if items[jj] == items[ii] {
+					return ItemError{Item: ii + 1, Err: fmt.Errorf("repeats item %d", jj+1)}
+				}
+			}
+		}
+	}
+	*v.Value = items
+	return nil
+}
+
+func (v List[T]) Clear() {
+	*v.Value = nil
+}
+
+func (v List[T]) Schema(s form.Schema) {
+	var (
+		item  T
+		items = form.Schema{}
+	)
+	form.Describe(v.Of(&item), items)
+	s["type"] = "array"
+	s["items"] = items
+	if v.MinItems > 0 {
+		s["minItems"] = v.MinItems
+	}
+	if v.MaxItems > 0 {
+		s["maxItems"] = v.MaxItems
+	}
+	if v.Unique {
+		s["uniqueItems"] = true
+	}
+}
+
+// splitItems splits text into items at the separator, unquoting quoted items.
+func splitItems(text, sep string) ([]string, error) {
+	var items []string
+	for rest := text; ; {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if !strings.HasPrefix(rest, `"`) {
+			item, after, found := strings.Cut(rest, sep)
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+			if !found {
+				return items, nil
+			}
+			rest = after
+			continue
+		}
+		item, after, err := unquote(rest)
+		if err != nil {
+			return nil, ItemError{Item: len(items) + 1, Err: err}
+		}
+		items = append(items, item)
+		trimmed := strings.TrimLeftFunc(after, unicode.IsSpace)
+		switch {
+		case trimmed == "":
+			return items, nil
+		case strings.HasPrefix(trimmed, sep):
+			rest = trimmed[len(sep):]
+		case strings.TrimSpace(sep) == "" && trimmed != after:
+			// The whitespace separator was trimmed.
+			rest = trimmed
+		default:
+			return nil, ItemError{Item: len(items), Err: fmt.Errorf("must be followed by %q", sep)}
+		}
+	}
+}
+
+// unquote reads a quoted item from the start of text, returning the item and the text after it.
+func unquote(text string) (item, rest string, err error) {
+	var b strings.Builder
+	for ii := 1; ; {
+		end := strings.IndexByte(text[ii:], '"')
+		if end < 0 {
+			return "", "", fmt.Errorf("missing closing quote")
+		}
+		b.WriteString(text[ii : ii+end])
+		ii += end + 1
+		if !strings.HasPrefix(text[ii:], `"`) {
+			return b.String(), text[ii:], nil
+		}
+		b.WriteByte('"')
+		ii++
+	}
+}
@@ -0,0 +1,105 @@
+package value
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+func TestList(t *testing.T) {
+	var (
+		tags []string
+		v    = List[string]{Value: &tags, Of: func(s *string) form.Value { return Text{Value: s} }}
+	)
+	for text, want := range map[string][]string{
+		"a, b ,c":           {"a", "b", "c"},
+		"a,,b,":             {"a", "b"},
+		`"a, b", c`:         {"a, b", "c"},
+		`"say ""hi""" , ""`: {`say "hi"`, ""},
+		"":                  {},
+		"  ":                {},
+		`" padded "`:        {" padded "},
+	} {
+		if err := v.From(text); err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if len(want) == 0 && len(tags) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(tags, want) {
+			t.Fatalf("%q: want %q, got %q", text, want, tags)
+		}
+	}
+	for text, want := range map[string]string{
+		`"a`:    "item 1: missing closing quote",
+		`"a" b`: `item 1: must be followed by ","`,
+	} {
+		if err := v.From(text); errText(err) != want {
+			t.Fatalf("%q: want %q, got %v", text, want, err)
+		}
+	}
+	tags = []string{"a, b", `say "hi"`, "c"}
+	if text, _ := v.To(); text != `"a, b", "say ""hi""", c` {
+		t.Fatalf("unexpected text: %s", text)
+	}
+}
+
+func TestListItems(t *testing.T) {
+	var (
+		ids []int
+		v   = List[int]{
+			Value:     &ids,
+			Of:        func(n *int) form.Value { return Int{Value: n} },
+			Separator: ";",
+			MinItems:  1,
+			MaxItems:  3,
+			Unique:    true,
+		}
+	)
+	if err := v.From("1; 2;3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "1; 2; 3" {
+		t.Fatalf("want %q, got %q", "1; 2; 3", text)
+	}
+	for text, want := range map[string]string{
+		"1;2;x":   "item 3: must be a valid number",
+		"1;2;1":   "item 3: repeats item 1",
+		"":        "must have at least 1 item",
+		"1;2;3;4": "must have at most 3 items",
+	} {
+		if err := v.From(text); errText(err) != want {
+			t.Fatalf("%q: want %q, got %v", text, want, err)
+		}
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2, 3}) {
+		t.Fatalf("want model untouched on error, got %v", ids)
+	}
+	var item ItemError
+	if err := v.From("1;x"); !errors.As(err, &item) || item.Item != 2 {
+		t.Fatalf("want item error for item 2, got %v", err)
+	}
+	s := form.Schema{}
+	form.Describe(v, s)
+	if s["type"] != "array" || s["items"].(form.Schema)["type"] != "integer" || s["uniqueItems"] != true || s["maxItems"] != 3 {
+		t.Fatalf("unexpected schema: %v", s)
+	}
+}
+
+func TestListLines(t *testing.T) {
+	var (
+		lines []string
+		v     = List[string]{Value: &lines, Of: func(s *string) form.Value { return Text{Value: s} }, Separator: "\n"}
+	)
+	if err := v.From("a b\n\"c\"\nd\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(lines, []string{"a b", "c", "d"}) {
+		t.Fatalf("unexpected lines: %q", lines)
+	}
+	if text, _ := v.To(); text != "a b\nc\nd" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+}
@@ -0,0 +1,217 @@
+package value
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// Mask maps text displayed through a pattern, such as "(999) 999-9999", to the characters typed
+// into its slots, such as "5551234567".
+//
+// In the pattern '9' is a digit slot, 'a' a letter slot and '*' a letter or digit slot. Other
+// characters are literals, displayed but not stored. A backslash makes the next character a
+// literal, such as `\9`.
+//
+// Mask is a `form.Formatter`, so the form applies the mask as the user types.
+type Mask struct {
+	Value   *string
+	Pattern string
+}
+
+// slot is a position in a mask pattern: either a class of character or a literal.
+type slot struct {
+	class   rune
+	literal rune
+}
+
+func (v Mask) slots() []slot {
+	var (
+		slots  []slot
+		escape bool
+	)
+	for _, r := range v.Pattern {
+		switch {
+		case escape:
+			slots = append(slots, slot{literal: r})
+			escape = false
+		case r == '\\':
+			escape = true
+		case r == '9' || r == 'a' || r == '*':
+			slots = append(slots, slot{class: r})
+		default:
+			slots = append(slots, slot{literal: r})
+		}
+	}
+	return slots
+}
+
+func (s slot) fits(r rune) bool {
+	switch s.class {
+	case '9':
+		return unicode.IsDigit(r)
+	case 'a':
+		return unicode.IsLetter(r)
+	case '*':
+		return unicode.IsDigit(r) || unicode.IsLetter(r)
+	}
+	return false
+}
+
+func (s slot) name() string {
+	switch s.class {
+	case '9':
+		return "digit"
+	case 'a':
+		return "letter"
+	}
+	return "letter or digit"
+}
+
+// strip reads the characters typed into the slots, ignoring literals and space, and maps the
+// caret, a byte offset into text, to the number of characters typed before it.
+func (v Mask) strip(text string, caret int) (raw []rune, rawCaret int, err error) {
+	var (
+		slots = v.slots()
+		p     int
+	)
+	rawCaret = -1
+	for offset, r := range text {
+		if offset >= caret && rawCaret < 0 {
+			rawCaret = len(raw)
+		}
+		// Skip literals up to the next slot, unless typed.
+		literal := false
+		for p < len(slots) && slots[p].class == 0 {
+			p++
+			if slots[p-1].literal == r {
+				literal = true
+				break
+			}
+		}
+		switch {
+		case literal:
+		case p < len(slots) && slots[p].fits(r):
+			raw = append(raw, r)
+			p++
+		case unicode.IsSpace(r) || v.isLiteral(r):
+		case p >= len(slots):
+			if err == nil {
+				err = fmt.Errorf("must have at most %s", plural(v.count(), v.noun()))
+			}
+		default:
+			if err == nil {
+				err = fmt.Errorf("must have a %s, not %q", slots[p].name(), r)
+			}
+		}
+	}
+	if rawCaret < 0 {
+		rawCaret = len(raw)
+	}
+	return raw, rawCaret, err
+}
+
+// apply displays raw characters through the pattern, up to the last character, mapping the raw
+// caret to a byte offset into the result.
+func (v Mask) apply(raw []rune, rawCaret int) (string, int) {
+	var (
+		b     strings.Builder
+		n     int
+		caret = -1
+	)
+	for _, s := range v.slots() {
+		if n >= len(raw) {
+			break
+		}
+		if s.class == 0 {
+			b.WriteRune(s.literal)
+			continue
+		}
+		if n == rawCaret {
+			caret = b.Len()
+		}
+		b.WriteRune(raw[n])
+		n++
+	}
+	if caret < 0 {
+		caret = b.Len()
+	}
+	return b.String(), caret
+}
+
+func (v Mask) isLiteral(r rune) bool {
+	for _, s := range v.slots() {
+		if s.class == 0 && s.literal == r {
+			return true
+		}
+	}
+	return false
+}
+
+// count is the number of slots.
+func (v Mask) count() int {
+	n := 0
+	for _, s := range v.slots() {
+		if s.class != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// noun describes what the slots hold.
+func (v Mask) noun() string {
+	for _, s := range v.slots() {
+		if s.class != 0 && s.class != '9' {
+			return "character"
+		}
+	}
+	return "digit"
+}
+
+// Format applies the mask to partial text.
+func (v Mask) Format(text string, caret int) (string, int) {
+	raw, rawCaret, _ := v.strip(text, caret)
+	return v.apply(raw, rawCaret)
+}
+
+func (v Mask) To() (string, error) {
+	text, _ := v.apply([]rune(*v.Value), 0)
+	return text, nil
+}
+
+func (v Mask) From(text string) error {
+	raw, _, err := v.strip(text, len(text))
+	if err != nil {
+		return err
+	}
+	if len(raw) > 0 && len(raw) < v.count() {
+		return fmt.Errorf("must have %s", plural(v.count(), v.noun()))
+	}
+	*v.Value = string(raw)
+	return nil
+}
+
+func (v Mask) Clear() {
+	*v.Value = ""
+}
+
+func (v Mask) Schema(s form.Schema) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for _, slot := range v.slots() {
+		switch slot.class {
+		case '9':
+			pattern.WriteString(`\d`)
+		case 'a':
+			pattern.WriteString(`\p{L}`)
+		case '*':
+			pattern.WriteString(`[\p{L}\d]`)
+		}
+	}
+	pattern.WriteString("$")
+	s["type"] = "string"
+	s["pattern"] = pattern.String()
+}
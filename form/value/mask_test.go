@@ -0,0 +1,99 @@
+package value
+
+import (
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+func TestMask(t *testing.T) {
+	var (
+		phone string
+		v     = Mask{Value: &phone, Pattern: "(999) 999-9999"}
+	)
+	for text, want := range map[string]string{
+		"(555) 123-4567": "5551234567",
+		"555 123 4567":   "5551234567",
+		"555-123-4567":   "5551234567",
+		"5551234567":     "5551234567",
+		"":               "",
+	} {
+		if err := v.From(text); err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if phone != want {
+			t.Fatalf("%q: want %q, got %q", text, want, phone)
+		}
+	}
+	phone = "5551234567"
+	if text, _ := v.To(); text != "(555) 123-4567" {
+		t.Fatalf("want %q, got %q", "(555) 123-4567", text)
+	}
+	for text, want := range map[string]string{
+		"555 123":          "must have 10 digits",
+		"555 123 456x":     `must have a digit, not 'x'`,
+		"(555) 123-4567 8": "must have at most 10 digits",
+	} {
+		if err := v.From(text); errText(err) != want {
+			t.Fatalf("%q: want %q, got %v", text, want, err)
+		}
+	}
+	if phone != "5551234567" {
+		t.Fatalf("want model untouched on error, got %q", phone)
+	}
+	s := form.Schema{}
+	form.Describe(v, s)
+	if s["pattern"] != `^\d\d\d\d\d\d\d\d\d\d$` {
+		t.Fatalf("unexpected schema: %v", s)
+	}
+}
+
+func TestMaskLiterals(t *testing.T) {
+	var (
+		code string
+		v    = Mask{Value: &code, Pattern: `+61 999 \9aa-**`}
+	)
+	for _, text := range []string{"+61 412 9AB-c1", "412AB c1", "+614129ABc1"} {
+		if err := v.From(text); err != nil {
+			t.Fatalf("%q: unexpected error: %v", text, err)
+		}
+		if code != "412ABc1" {
+			t.Fatalf("%q: want %q, got %q", text, "412ABc1", code)
+		}
+	}
+	if text, _ := v.To(); text != "+61 412 9AB-c1" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+	if err := v.From("412 9#B"); errText(err) != `must have a letter, not '#'` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMaskFormat(t *testing.T) {
+	v := Mask{Pattern: "(999) 999-9999"}
+	for _, tt := range []struct {
+		text  string
+		caret int
+		want  string
+		at    int
+	}{
+		// Typing at the end.
+		{"5", 1, "(5", 2},
+		{"(5551", 5, "(555) 1", 7},
+		{"(555) 1234", 10, "(555) 123-4", 11},
+		// Deleting a trailing literal leaves the digits.
+		{"(555", 4, "(555", 4},
+		// Typing in the middle keeps the caret after the typed digit.
+		{"(5955) 123-4", 3, "(595) 512-34", 3},
+		// Pasting unformatted text.
+		{"5551234567", 10, "(555) 123-4567", 14},
+		// Invalid characters are dropped.
+		{"(55x", 4, "(55", 3},
+		{"", 0, "", 0},
+	} {
+		got, at := v.Format(tt.text, tt.caret)
+		if got != tt.want || at != tt.at {
+			t.Fatalf("%q at %d: want %q at %d, got %q at %d", tt.text, tt.caret, tt.want, tt.at, got, at)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package value
+
+import (
+	"net/netip"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// Email maps text to an email address. See `parse.Email`.
+type Email struct {
+	Value *string
+	// Verbatim stores the address as written, rather than trimmed with a lowercase domain.
+	Verbatim bool
+}
+
+func (v Email) of() Of[string] {
+	return Of[string]{
+		Value: v.Value,
+		Parse: func(text string) (string, error) {
+			addr, err := parse.Email(text)
+			if err != nil || !v.Verbatim {
+				return addr, err
+			}
+			return text, nil
+		},
+		Format: func(addr string) string { return addr },
+	}
+}
+
+func (v Email) To() (string, error) {
+	return v.of().To()
+}
+
+func (v Email) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v Email) Clear() {
+	v.of().Clear()
+}
+
+func (v Email) Schema(s form.Schema) {
+	s["type"] = "string"
+	s["format"] = "email"
+}
+
+// URL maps text to an absolute URL. See `parse.URL`.
+type URL struct {
+	Value *string
+	// Schemes restricts the URL scheme, such as "https". Any scheme is allowed if empty.
+	Schemes []string
+}
+
+func (v URL) of() Of[string] {
+	return Of[string]{
+		Value: v.Value,
+		Parse: func(text string) (string, error) {
+			u, err := parse.URL(text, v.Schemes...)
+			if err != nil {
+				return "", err
+			}
+			return u.String(), nil
+		},
+		Format: func(u string) string { return u },
+	}
+}
+
+func (v URL) To() (string, error) {
+	return v.of().To()
+}
+
+func (v URL) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v URL) Clear() {
+	v.of().Clear()
+}
+
+func (v URL) Schema(s form.Schema) {
+	s["type"] = "string"
+	s["format"] = "uri"
+}
+
+// Phone maps text to a telephone number in E.164 form. See `parse.Phone`.
+type Phone struct {
+	Value *string
+	// Region interprets numbers without a country code, such as "AU".
+	Region string
+}
+
+func (v Phone) of() Of[string] {
+	return Of[string]{
+		Value:  v.Value,
+		Parse:  func(text string) (string, error) { return parse.Phone(text, v.Region) },
+		Format: func(number string) string { return number },
+	}
+}
+
+func (v Phone) To() (string, error) {
+	return v.of().To()
+}
+
+func (v Phone) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v Phone) Clear() {
+	v.of().Clear()
+}
+
+func (v Phone) Schema(s form.Schema) {
+	s["type"] = "string"
+	s["pattern"] = `^\+[1-9][0-9]{7,14}$`
+}
+
+// IP maps text to an IP address. See `parse.IP`.
+type IP struct {
+	Value *netip.Addr
+	// Version restricts addresses to IPv4 or IPv6 if 4 or 6.
+	Version int
+}
+
+func (v IP) of() Of[netip.Addr] {
+	return Of[netip.Addr]{
+		Value: v.Value,
+		Parse: func(text string) (netip.Addr, error) { return parse.IP(text, v.Version) },
+		Format: func(addr netip.Addr) string {
+			if !addr.IsValid() {
+				return ""
+			}
+			return addr.String()
+		},
+	}
+}
+
+func (v IP) To() (string, error) {
+	return v.of().To()
+}
+
+func (v IP) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v IP) Clear() {
+	v.of().Clear()
+}
+
+func (v IP) Schema(s form.Schema) {
+	s["type"] = "string"
+	switch v.Version {
+	case 4:
+		s["format"] = "ipv4"
+	case 6:
+		s["format"] = "ipv6"
+	}
+}
+
+// CIDR maps text to an IP prefix. See `parse.CIDR`.
+type CIDR struct {
+	Value *netip.Prefix
+	// Version restricts prefixes to IPv4 or IPv6 if 4 or 6.
+	Version int
+	// Strict rejects prefixes with host bits set.
+	Strict bool
+}
+
+func (v CIDR) of() Of[netip.Prefix] {
+	return Of[netip.Prefix]{
+		Value: v.Value,
+		Parse: func(text string) (netip.Prefix, error) { return parse.CIDR(text, v.Version, v.Strict) },
+		Format: func(prefix netip.Prefix) string {
+			if !prefix.IsValid() {
+				return ""
+			}
+			return prefix.String()
+		},
+	}
+}
+
+func (v CIDR) To() (string, error) {
+	return v.of().To()
+}
+
+func (v CIDR) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v CIDR) Clear() {
+	v.of().Clear()
+}
+
+func (v CIDR) Schema(s form.Schema) {
+	s["type"] = "string"
+}
@@ -0,0 +1,88 @@
+package value
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestEmail(t *testing.T) {
+	var addr string
+	if err := (Email{Value: &addr}).From(" Jack@Example.COM "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr != "Jack@example.com" {
+		t.Fatalf("want %q, got %q", "Jack@example.com", addr)
+	}
+	if err := (Email{Value: &addr, Verbatim: true}).From("Jack@Example.COM"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr != "Jack@Example.COM" {
+		t.Fatalf("want %q, got %q", "Jack@Example.COM", addr)
+	}
+	if err := (Email{Value: &addr}).From("jack"); err == nil {
+		t.Fatalf("want error, got nil")
+	}
+	if addr != "Jack@Example.COM" {
+		t.Fatalf("want model untouched on error, got %q", addr)
+	}
+}
+
+func TestURL(t *testing.T) {
+	var u string
+	v := URL{Value: &u, Schemes: []string{"https"}}
+	if err := v.From("http://example.com"); err == nil || err.Error() != "must start with https://" {
+		t.Fatalf("want %q, got %v", "must start with https://", err)
+	}
+	if err := v.From(" https://example.com/a "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "https://example.com/a" {
+		t.Fatalf("want %q, got %q", "https://example.com/a", text)
+	}
+}
+
+func TestPhone(t *testing.T) {
+	var number string
+	v := Phone{Value: &number, Region: "AU"}
+	if err := v.From("0412 345 678"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "+61412345678" {
+		t.Fatalf("want %q, got %q", "+61412345678", text)
+	}
+}
+
+func TestIP(t *testing.T) {
+	var addr netip.Addr
+	v := IP{Value: &addr, Version: 4}
+	if text, _ := v.To(); text != "" {
+		t.Fatalf("want empty text for unset address, got %q", text)
+	}
+	if err := v.From("::1"); err == nil || err.Error() != "must be an IPv4 address" {
+		t.Fatalf("want %q, got %v", "must be an IPv4 address", err)
+	}
+	if err := v.From("192.0.2.1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "192.0.2.1" {
+		t.Fatalf("want %q, got %q", "192.0.2.1", text)
+	}
+	v.Clear()
+	if addr.IsValid() {
+		t.Fatalf("want cleared address, got %v", addr)
+	}
+}
+
+func TestCIDR(t *testing.T) {
+	var prefix netip.Prefix
+	v := CIDR{Value: &prefix, Strict: true}
+	if err := v.From("10.1.2.3/8"); err == nil {
+		t.Fatalf("want error, got nil")
+	}
+	if err := v.From("10.0.0.0/8"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := v.To(); text != "10.0.0.0/8" {
+		t.Fatalf("want %q, got %q", "10.0.0.0/8", text)
+	}
+}
@@ -0,0 +1,430 @@
+package value
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// Numeric is implemented by values that hold a number, allowing numeric constraints to inspect
+// them.
+type Numeric interface {
+	Number() float64
+}
+
+func (v Int) Number() float64 {
+	return float64(*v.Value)
+}
+
+func (v Float) Number() float64 {
+	return *v.Value
+}
+
+// NumberParser is implemented by values that parse text into a number without storing it,
+// allowing numeric constraints to check the number before the value stores it.
+//
+// Text that holds no number, such as the empty text of an `Optional` value, parses to NaN, which
+// numeric constraints let through.
+type NumberParser interface {
+	ParseNumber(text string) (float64, error)
+}
+
+func (v Int) ParseNumber(text string) (float64, error) {
+	n, err := v.of().Parse(text)
+	return float64(n), err
+}
+
+func (v Float) ParseNumber(text string) (float64, error) {
+	return v.of().Parse(text)
+}
+
+// numberer is implemented by typed values that convert structured data to the number they store,
+// allowing numeric constraints to check data before the value stores it.
+type numberer interface {
+	toNumber(data any) (float64, error)
+}
+
+func (v Int) toNumber(data any) (float64, error) {
+	n, ok := toFloat(data)
+	if !ok {
+		return 0, fmt.Errorf("want number, got %T", data)
+	}
+	return math.Round(n), nil
+}
+
+func (v Float) toNumber(data any) (float64, error) {
+	n, ok := toFloat(data)
+	if !ok {
+		return 0, fmt.Errorf("want number, got %T", data)
+	}
+	return n, nil
+}
+
+// rewriter is implemented by wrappers that rewrite text before the value they wrap parses it.
+type rewriter interface {
+	rewrite(text string) string
+}
+
+// parseNumber parses text with the number parser of v, or any value it wraps, rewriting the text
+// as the wrappers in between do.
+func parseNumber(v form.Value, text string) (float64, error) {
+	for v != nil {
+		if p, ok := v.(NumberParser); ok {
+			return p.ParseNumber(text)
+		}
+		if r, ok := v.(rewriter); ok {
+			text = r.rewrite(text)
+		}
+		unwrapper, ok := v.(form.Unwrapper)
+		if !ok {
+			break
+		}
+		v = unwrapper.Unwrap()
+	}
+	return 0, fmt.Errorf("not a number")
+}
+
+// dataNumber converts structured data with the numberer of v, or any value it wraps.
+func dataNumber(v form.Value, data any) (float64, error) {
+	for v != nil {
+		if n, ok := v.(numberer); ok {
+			return n.toNumber(data)
+		}
+		unwrapper, ok := v.(form.Unwrapper)
+		if !ok {
+			break
+		}
+		v = unwrapper.Unwrap()
+	}
+	if n, ok := toFloat(data); ok {
+		return n, nil
+	}
+	return 0, fmt.Errorf("want number, got %T", data)
+}
+
+// Bound names a kind of numeric constraint.
+type Bound string
+
+const (
+	Minimum    Bound = "minimum"
+	Maximum    Bound = "maximum"
+	MultipleOf Bound = "multipleOf"
+	Decimals   Bound = "decimals"
+)
+
+// BoundError reports a number that violates a constraint.
+type BoundError struct {
+	Bound     Bound
+	Limit     float64
+	Exclusive bool
+}
+
+func (err BoundError) Error() string {
+	limit := strconv.FormatFloat(err.Limit, 'f', -1, 64)
+	switch err.Bound {
+	case Minimum:
+		if err.Exclusive {
+			return fmt.Sprintf("must be greater than %s", limit)
+		}
+		return fmt.Sprintf("must be at least %s", limit)
+	case Maximum:
+		if err.Exclusive {
+			return fmt.Sprintf("must be less than %s", limit)
+		}
+		return fmt.Sprintf("must be at most %s", limit)
+	case MultipleOf:
+		return fmt.Sprintf("must be a multiple of %s", limit)
+	case Decimals:
+		if err.Limit == 0 {
+			return "must be a whole number"
+		}
+		return fmt.Sprintf("must have at most %s decimal places", limit)
+	}
+	return fmt.Sprintf("must satisfy %s %s", err.Bound, limit)
+}
+
+// Bounds describes the numeric constraints on a value, for widgets such as sliders and steppers
+// that need to know the valid range up front.
+type Bounds struct {
+	Min, Max                   float64
+	HasMin, HasMax             bool
+	ExclusiveMin, ExclusiveMax bool
+	// Step is the multiple that numbers must be, or zero if unconstrained.
+	Step float64
+	// Places is the maximum number of decimal places, or -1 if unconstrained.
+	Places int
+}
+
+// BoundsOf collects the numeric constraints of v and any value it wraps.
+func BoundsOf(v form.Value) Bounds {
+	b := Bounds{Places: -1}
+	for v != nil {
+		if bounder, ok := v.(interface{ bound(*Bounds) }); ok {
+			bounder.bound(&b)
+		}
+		unwrapper, ok := v.(form.Unwrapper)
+		if !ok {
+			break
+		}
+		v = unwrapper.Unwrap()
+	}
+	return b
+}
+
+// checkNumber checks the number parsed from text before v stores it, such that a rejected number
+// leaves the model untouched.
+func checkNumber(v form.Value, text string, check func(n float64) error) error {
+	n, err := parseNumber(v, text)
+	if err != nil {
+		return err
+	}
+	if !math.IsNaN(n) {
+		if err := check(n); err != nil {
+			return err
+		}
+	}
+	return v.From(text)
+}
+
+// setNumber checks the number converted from structured data before v stores it, such that a
+// rejected number leaves the model untouched.
+func setNumber(v form.Value, data any, check func(n float64) error) error {
+	typed, err := typedValue(v)
+	if err != nil {
+		return err
+	}
+	n, err := dataNumber(v, data)
+	if err != nil {
+		return err
+	}
+	if err := check(n); err != nil {
+		return err
+	}
+	return typed.Set(data)
+}
+
+// Min rejects numbers below a lower bound.
+type Min struct {
+	form.Value
+	Min float64
+	// Exclusive rejects the bound itself.
+	Exclusive bool
+}
+
+func (v Min) From(text string) error {
+	return checkNumber(v.Value, text, v.check)
+}
+
+func (v Min) Get() (any, error) {
+	return getData(v.Value)
+}
+
+func (v Min) Set(data any) error {
+	return setNumber(v.Value, data, v.check)
+}
+
+func (v Min) check(n float64) error {
+	if n < v.Min || (v.Exclusive && n == v.Min) {
+		return BoundError{Bound: Minimum, Limit: v.Min, Exclusive: v.Exclusive}
+	}
+	return nil
+}
+
+func (v Min) Unwrap() form.Value {
+	return v.Value
+}
+
+func (v Min) Schema(s form.Schema) {
+	form.Describe(v.Value, s)
+	if v.Exclusive {
+		s["exclusiveMinimum"] = v.Min
+	} else {
+		s["minimum"] = v.Min
+	}
+}
+
+func (v Min) bound(b *Bounds) {
+	if !b.HasMin || v.Min > b.Min {
+		b.Min, b.HasMin, b.ExclusiveMin = v.Min, true, v.Exclusive
+	}
+}
+
+// Max rejects numbers above an upper bound.
+type Max struct {
+	form.Value
+	Max float64
+	// Exclusive rejects the bound itself.
+	Exclusive bool
+}
+
+func (v Max) From(text string) error {
+	return checkNumber(v.Value, text, v.check)
+}
+
+func (v Max) Get() (any, error) {
+	return getData(v.Value)
+}
+
+func (v Max) Set(data any) error {
+	return setNumber(v.Value, data, v.check)
+}
+
+func (v Max) check(n float64) error {
+	if n > v.Max || (v.Exclusive && n == v.Max) {
+		return BoundError{Bound: Maximum, Limit: v.Max, Exclusive: v.Exclusive}
+	}
+	return nil
+}
+
+func (v Max) Unwrap() form.Value {
+	return v.Value
+}
+
+func (v Max) Schema(s form.Schema) {
+	form.Describe(v.Value, s)
+	if v.Exclusive {
+		s["exclusiveMaximum"] = v.Max
+	} else {
+		s["maximum"] = v.Max
+	}
+}
+
+func (v Max) bound(b *Bounds) {
+	if !b.HasMax || v.Max < b.Max {
+		b.Max, b.HasMax, b.ExclusiveMax = v.Max, true, v.Exclusive
+	}
+}
+
+// Step rejects numbers that aren't a multiple of the step, such as quantities sold in fives.
+type Step struct {
+	form.Value
+	Step float64
+}
+
+func (v Step) From(text string) error {
+	return checkNumber(v.Value, text, v.check)
+}
+
+func (v Step) Get() (any, error) {
+	return getData(v.Value)
+}
+
+func (v Step) Set(data any) error {
+	return setNumber(v.Value, data, v.check)
+}
+
+func (v Step) check(n float64) error {
+	if !isMultiple(n, v.Step) {
+		return BoundError{Bound: MultipleOf, Limit: v.Step}
+	}
+	return nil
+}
+
+func (v Step) Unwrap() form.Value {
+	return v.Value
+}
+
+func (v Step) Schema(s form.Schema) {
+	form.Describe(v.Value, s)
+	multipleOf(s, v.Step)
+}
+
+func (v Step) bound(b *Bounds) {
+	if b.Step == 0 {
+		b.Step = v.Step
+	}
+}
+
+// Places rejects numbers with more than the given number of decimal places.
+// An explicitly chosen fixed float format rounds parsed numbers before they can be checked.
+type Places struct {
+	form.Value
+	Places int
+}
+
+func (v Places) From(text string) error {
+	return checkNumber(v.Value, text, v.check)
+}
+
+func (v Places) Get() (any, error) {
+	return getData(v.Value)
+}
+
+func (v Places) Set(data any) error {
+	return setNumber(v.Value, data, v.check)
+}
+
+func (v Places) check(n float64) error {
+	if decimals(n) > v.Places {
+		return BoundError{Bound: Decimals, Limit: float64(v.Places)}
+	}
+	return nil
+}
+
+func (v Places) Unwrap() form.Value {
+	return v.Value
+}
+
+func (v Places) Schema(s form.Schema) {
+	form.Describe(v.Value, s)
+	multipleOf(s, math.Pow10(-v.Places))
+}
+
+func (v Places) bound(b *Bounds) {
+	if b.Places < 0 || v.Places < b.Places {
+		b.Places = v.Places
+	}
+}
+
+// decimals counts the decimal places in the shortest representation of n.
+func decimals(n float64) int {
+	s := strconv.FormatFloat(n, 'f', -1, 64)
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		return len(s) - dot - 1
+	}
+	return 0
+}
+
+// multipleOf adds a multiple to the schema alongside any already described, keeping the stricter
+// of the two where one is a multiple of the other and requiring both otherwise.
+func multipleOf(s form.Schema, m float64) {
+	prev, ok := toFloat(s["multipleOf"])
+	switch {
+	case !ok:
+		s["multipleOf"] = m
+	case isMultiple(prev, m):
+	case isMultiple(m, prev):
+		s["multipleOf"] = m
+	default:
+		all, _ := s["allOf"].([]any)
+		s["allOf"] = append(all, form.Schema{"multipleOf": m})
+	}
+}
+
+// isMultiple reports whether n is a multiple of m.
+func isMultiple(n, m float64) bool {
+	q := n / m
+	return math.Abs(q-math.Round(q)) <= 1e-9
+}
+
+// toFloat converts structured numeric data, such as the float32 of a slider, to float64.
+func toFloat(data any) (float64, bool) {
+	switch n := data.(type) {
+	case float64:
+		return n, true
+	case float32:
+		// Take the shortest decimal, such that 0.3 isn't stored as 0.30000001.
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(n), 'g', -1, 32), 64)
+		return f, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	}
+	return 0, false
+}
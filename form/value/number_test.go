@@ -0,0 +1,139 @@
+package value
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+func TestNumberConstraints(t *testing.T) {
+	var (
+		n float64
+		f = Float{Value: &n}
+	)
+	for _, tt := range []struct {
+		Label string
+		Value form.Value
+		Text  string
+		Err   error
+	}{
+		{"min", Min{Value: f, Min: 0}, "0", nil},
+		{"min violated", Min{Value: f, Min: 0}, "-1", BoundError{Bound: Minimum, Limit: 0}},
+		{"exclusive min", Min{Value: f, Min: 0, Exclusive: true}, "0", BoundError{Bound: Minimum, Limit: 0, Exclusive: true}},
+		{"max", Max{Value: f, Max: 130}, "130", nil},
+		{"max violated", Max{Value: f, Max: 130}, "131", BoundError{Bound: Maximum, Limit: 130}},
+		{"step", Step{Value: f, Step: 5}, "15", nil},
+		{"step violated", Step{Value: f, Step: 5}, "16", BoundError{Bound: MultipleOf, Limit: 5}},
+		{"fractional step", Step{Value: f, Step: 0.1}, "0.3", nil},
+		{"places", Places{Value: f, Places: 2}, "1.25", nil},
+		{"places violated", Places{Value: f, Places: 2}, "1.255", BoundError{Bound: Decimals, Limit: 2}},
+		{"nested", Min{Value: Required{Max{Value: f, Max: 10}}, Min: 1}, "0", BoundError{Bound: Minimum, Limit: 1}},
+	} {
+		t.Run(tt.Label, func(t *testing.T) {
+			err := tt.Value.From(tt.Text)
+			if err != tt.Err {
+				t.Fatalf("want %v, got %v", tt.Err, err)
+			}
+		})
+	}
+}
+
+func TestNumberConstraintsBeforeStore(t *testing.T) {
+	age := 30
+	for _, v := range []form.Value{
+		Max{Value: Int{Value: &age}, Max: 130},
+		Min{Value: Int{Value: &age}, Min: 300},
+		Step{Value: Int{Value: &age}, Step: 3},
+	} {
+		if err := v.From("200"); err == nil {
+			t.Fatalf("%T: want error, got nil", v)
+		}
+		if age != 30 {
+			t.Fatalf("%T: want model untouched on bound error, got %d", v, age)
+		}
+		if err := v.(form.TypedValue).Set(float32(200)); err == nil || age != 30 {
+			t.Fatalf("%T: want model untouched on typed bound error, got %d (%v)", v, age, err)
+		}
+	}
+	price := 1.5
+	if err := (Places{Value: Float{Value: &price}, Places: 1}).From("2.25"); err == nil || price != 1.5 {
+		t.Fatalf("want model untouched on places error, got %v (%v)", price, err)
+	}
+	// Wrapped values report their own errors first.
+	err := Max{Value: Required{Value: Int{Value: &age}}, Max: 130}.From(" ")
+	if err == nil || err.Error() != "required" {
+		t.Fatalf("want %q, got %v", "required", err)
+	}
+	err = Max{Value: Required{Value: Int{Value: &age}}, Max: 130}.Set(nil)
+	if err == nil || err.Error() != "required" {
+		t.Fatalf("want %q, got %v", "required", err)
+	}
+	// Values that don't parse numbers are never stored.
+	d := 24 * time.Hour
+	if err := (Max{Value: Days{Value: &d}, Max: 3}).From("5"); err == nil || d != 24*time.Hour {
+		t.Fatalf("want model untouched for a value without numbers, got %v (%v)", d, err)
+	}
+	// Optional and nullable numbers pass empty text and check the rest.
+	var p *int
+	v := Min{Value: OptionalInt(&p), Min: 10}
+	if err := v.From("5"); err == nil || p != nil {
+		t.Fatalf("want model untouched on optional bound error, got %v (%v)", p, err)
+	}
+	if err := v.From("12"); err != nil || p == nil || *p != 12 {
+		t.Fatalf("want 12, got %v (%v)", p, err)
+	}
+	if err := v.From(""); err != nil || p != nil {
+		t.Fatalf("want nil, got %v (%v)", p, err)
+	}
+	var n sql.NullInt64
+	null := Max{Value: Null[int64]{Value: &n, Parse: parse.Integer[int64], Format: FormatInteger[int64]}, Max: 3}
+	if err := null.From("5"); err == nil || n.Valid {
+		t.Fatalf("want model untouched on nullable bound error, got %v (%v)", n, err)
+	}
+	if err := null.From("2"); err != nil || n.Int64 != 2 {
+		t.Fatalf("want 2, got %v (%v)", n, err)
+	}
+	// Wrappers that rewrite text are followed, such that bounds check the rewritten text.
+	err = Max{Value: Default{Value: Int{Value: &age}, Text: "150"}, Max: 130}.From("")
+	if err == nil || err.Error() != "must be at most 130" || age != 30 {
+		t.Fatalf("want default checked against bound, got %v (%d)", err, age)
+	}
+}
+
+func TestMultipleOfSchema(t *testing.T) {
+	var (
+		n int
+		f float64
+	)
+	for _, tt := range []struct {
+		Value form.Value
+		Want  float64
+		AllOf any
+	}{
+		{Value: Step{Value: Places{Value: Int{Value: &n}, Places: 0}, Step: 5}, Want: 5},
+		{Value: Places{Value: Step{Value: Float{Value: &f}, Step: 5}, Places: 2}, Want: 5},
+		{Value: Places{Value: Step{Value: Float{Value: &f}, Step: 0.25}, Places: 1}, Want: 0.25, AllOf: []any{form.Schema{"multipleOf": 0.1}}},
+	} {
+		s := form.Schema{}
+		form.Describe(tt.Value, s)
+		if s["multipleOf"] != tt.Want || !reflect.DeepEqual(s["allOf"], tt.AllOf) {
+			t.Fatalf("%#v: want multipleOf %v and allOf %v, got %v", tt.Value, tt.Want, tt.AllOf, s)
+		}
+	}
+}
+
+func TestBoundsOf(t *testing.T) {
+	var age int
+	v := Required{Min{Value: Max{Value: Step{Value: Int{Value: &age}, Step: 5}, Max: 130}, Min: 0}}
+	want := Bounds{Min: 0, HasMin: true, Max: 130, HasMax: true, Step: 5, Places: -1}
+	if got := BoundsOf(v); got != want {
+		t.Fatalf("\nwant: %+v\n got: %+v", want, got)
+	}
+	if err := v.From("135"); err == nil || err.Error() != "must be at most 130" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package value
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// Of maps text to a value of type T via a pair of parse and format functions.
+//
+// Most types need only supply the functions:
+//
+//	value.Of[uint16]{Value: &port, Parse: parse.Integer[uint16], Format: value.FormatInteger[uint16]}
+type Of[T comparable] struct {
+	Value *T
+	// Default is displayed in place of an unset value.
+	Default T
+	// Parse converts text into T.
+	Parse func(string) (T, error)
+	// Format converts T into text.
+	Format func(T) string
+	// Unset reports whether the value is unset, such that the default is displayed.
+	// Defaults to comparing against the zero value of T.
+	Unset func(T) bool
+	// Reset returns the value stored by Clear.
+	// Defaults to the zero value of T.
+	Reset func() T
+	// Constraints validate the parsed value before it is stored.
+	Constraints []func(T) error
+}
+
+func (v Of[T]) To() (string, error) {
+	var t = *v.Value
+	if v.unset(t) {
+		t = v.Default
+	}
+	return v.Format(t), nil
+}
+
+func (v Of[T]) From(text string) error {
+	t, err := v.Parse(text)
+	if err != nil {
+		return err
+	}
+	for _, constraint := range v.Constraints {
+		if err := constraint(t); err != nil {
+			return err
+		}
+	}
+	*v.Value = t
+	return nil
+}
+
+func (v Of[T]) Clear() {
+	var t T
+	if v.Reset != nil {
+		t = v.Reset()
+	}
+	*v.Value = t
+}
+
+func (v Of[T]) Schema(s form.Schema) {
+	describeType(v.Default, s)
+}
+
+// describeType describes the JSON Schema type of a Go value, including named types such as
+// `type Priority int`.
+func describeType(v any, s form.Schema) {
+	if _, ok := v.(time.Time); ok {
+		// The layout of the text is up to the parse function, so no format is implied.
+		s["type"] = "string"
+		return
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s["type"] = "integer"
+	case reflect.Float32, reflect.Float64:
+		s["type"] = "number"
+	case reflect.String:
+		s["type"] = "string"
+	case reflect.Bool:
+		s["type"] = "boolean"
+	}
+}
+
+func (v Of[T]) unset(t T) bool {
+	if v.Unset != nil {
+		return v.Unset(t)
+	}
+	var zero T
+	return t == zero
+}
+
+// Integer maps text to an integer of any size.
+func Integer[T parse.Integral](value *T) Of[T] {
+	return Of[T]{Value: value, Parse: parse.Integer[T], Format: FormatInteger[T]}
+}
+
+// FormatInteger formats an integer of any size as decimal digits.
+func FormatInteger[T parse.Integral](n T) string {
+	if n < 0 {
+		return strconv.FormatInt(int64(n), 10)
+	}
+	return strconv.FormatUint(uint64(n), 10)
+}
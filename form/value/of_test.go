@@ -0,0 +1,42 @@
+package value
+
+import (
+	"testing"
+)
+
+func TestInteger(t *testing.T) {
+	var port uint16
+	v := Integer(&port)
+	for _, tt := range []struct {
+		Text string
+		Err  string
+		Want uint16
+	}{
+		{Text: "8080", Want: 8080},
+		{Text: "65536", Err: "must be between 0 and 65535"},
+		{Text: "-1", Err: "must not be negative"},
+		{Text: "port", Err: "must be a valid number"},
+	} {
+		port = 0
+		err := v.From(tt.Text)
+		if tt.Err != "" {
+			if err == nil || err.Error() != tt.Err {
+				t.Fatalf("%q: want error %q, got %v", tt.Text, tt.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.Text, err)
+		}
+		if port != tt.Want {
+			t.Fatalf("%q: want %d, got %d", tt.Text, tt.Want, port)
+		}
+		if text, _ := v.To(); text != tt.Text {
+			t.Fatalf("want %q, got %q", tt.Text, text)
+		}
+	}
+	var n int8
+	if err := Integer(&n).From("128"); err == nil || err.Error() != "must be between -128 and 127" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
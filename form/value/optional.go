@@ -0,0 +1,147 @@
+package value
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+// Optional maps text to a value that may be absent.
+// Empty text stores nil, and any parsed value is stored as is, including zero.
+// Wrap in `Required` to reject absent values.
+type Optional[T any] struct {
+	Value  **T
+	Parse  func(string) (T, error)
+	Format func(T) string
+}
+
+func (v Optional[T]) To() (string, error) {
+	if *v.Value == nil {
+		return "", nil
+	}
+	return v.Format(**v.Value), nil
+}
+
+func (v Optional[T]) From(text string) error {
+	if strings.TrimSpace(text) == "" {
+		*v.Value = nil
+		return nil
+	}
+	t, err := v.Parse(text)
+	if err != nil {
+		return err
+	}
+	*v.Value = &t
+	return nil
+}
+
+// ParseNumber parses numeric text, where empty text holds no number.
+func (v Optional[T]) ParseNumber(text string) (float64, error) {
+	if strings.TrimSpace(text) == "" {
+		return math.NaN(), nil
+	}
+	t, err := v.Parse(text)
+	if err != nil {
+		return 0, err
+	}
+	if n, ok := toFloat(t); ok {
+		return n, nil
+	}
+	return 0, fmt.Errorf("not a number")
+}
+
+func (v Optional[T]) Clear() {
+	*v.Value = nil
+}
+
+func (v Optional[T]) Schema(s form.Schema) {
+	var t T
+	describeType(t, s)
+	if typ, ok := s["type"].(string); ok {
+		s["type"] = []string{typ, "null"}
+	}
+}
+
+// OptionalInt maps text to an optional integer.
+func OptionalInt(value **int) Optional[int] {
+	return Optional[int]{Value: value, Parse: parse.Int, Format: strconv.Itoa}
+}
+
+// OptionalFloat maps text to an optional floating point number.
+func OptionalFloat(value **float64, format FloatFormat, l *parse.Locale) Optional[float64] {
+	return Optional[float64]{
+		Value:  value,
+		Parse:  func(text string) (float64, error) { return format.parse(locale(l), text) },
+		Format: func(n float64) string { return format.format(locale(l), n) },
+	}
+}
+
+// OptionalDate maps text to an optional date.
+func OptionalDate(value **time.Time) Optional[time.Time] {
+	return Optional[time.Time]{Value: value, Parse: parse.Date, Format: parse.FormatTime}
+}
+
+// Nullable is implemented by the `sql.Null*` family of types, and similar types that can hold
+// NULL.
+type Nullable interface {
+	sql.Scanner
+	driver.Valuer
+}
+
+// Null maps text to a nullable field, where empty text stores NULL.
+//
+// T is the driver value type of the field, for instance int64 for both `sql.NullInt64` and
+// `sql.NullInt32`, or string for `sql.NullString`.
+//
+//	value.Null[int64]{Value: &model.Age, Parse: parse.Integer[int64], Format: value.FormatInteger[int64]}
+type Null[T any] struct {
+	Value  Nullable
+	Parse  func(string) (T, error)
+	Format func(T) string
+}
+
+func (v Null[T]) To() (string, error) {
+	dv, err := v.Value.Value()
+	if err != nil {
+		return "", err
+	}
+	if dv == nil {
+		return "", nil
+	}
+	t, ok := dv.(T)
+	if !ok {
+		return "", fmt.Errorf("unexpected type %T", dv)
+	}
+	return v.Format(t), nil
+}
+
+func (v Null[T]) From(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return v.Value.Scan(nil)
+	}
+	t, err := v.Parse(text)
+	if err != nil {
+		return err
+	}
+	return v.Value.Scan(t)
+}
+
+// ParseNumber parses numeric text, where empty text holds no number.
+func (v Null[T]) ParseNumber(text string) (float64, error) {
+	return Optional[T]{Parse: v.Parse}.ParseNumber(text)
+}
+
+func (v Null[T]) Clear() {
+	_ = v.Value.Scan(nil)
+}
+
+func (v Null[T]) Schema(s form.Schema) {
+	Optional[T]{}.Schema(s)
+}
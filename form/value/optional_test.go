@@ -0,0 +1,57 @@
+package value
+
+import (
+	"database/sql"
+	"testing"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form/parse"
+)
+
+func TestOptional(t *testing.T) {
+	var age *int
+	v := OptionalInt(&age)
+	if err := v.From("0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if age == nil || *age != 0 {
+		t.Fatalf("want explicit zero, got %v", age)
+	}
+	if text, _ := v.To(); text != "0" {
+		t.Fatalf("want %q, got %q", "0", text)
+	}
+	if err := v.From(" "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if age != nil {
+		t.Fatalf("want nil, got %v", *age)
+	}
+	if text, _ := v.To(); text != "" {
+		t.Fatalf("want empty text, got %q", text)
+	}
+	if err := (Required{v}).From(""); err == nil {
+		t.Fatalf("want required error")
+	}
+}
+
+func TestNull(t *testing.T) {
+	var age sql.NullInt32
+	v := Null[int64]{Value: &age, Parse: parse.Integer[int64], Format: FormatInteger[int64]}
+	if err := v.From("0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !age.Valid || age.Int32 != 0 {
+		t.Fatalf("want valid zero, got %+v", age)
+	}
+	if text, _ := v.To(); text != "0" {
+		t.Fatalf("want %q, got %q", "0", text)
+	}
+	if err := v.From(""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if age.Valid {
+		t.Fatalf("want null, got %+v", age)
+	}
+	if err := v.From("zero"); err == nil {
+		t.Fatalf("want parse error")
+	}
+}
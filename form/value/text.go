@@ -0,0 +1,188 @@
+package value
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+// Unit is what the length of text is counted in.
+type Unit int
+
+const (
+	// Runes counts Unicode code points, as JSON Schema does.
+	Runes Unit = iota
+	// Graphemes counts user perceived characters, such that "é" written with a combining accent
+	// or a flag emoji count once.
+	Graphemes
+)
+
+// count the length of text in the unit.
+func (u Unit) count(text string) int {
+	if u == Graphemes {
+		return uniseg.GraphemeClusterCount(text)
+	}
+	return utf8.RuneCountInString(text)
+}
+
+// LengthError reports text that is too short or too long.
+type LengthError struct {
+	Bound Bound
+	Limit int
+}
+
+func (err LengthError) Error() string {
+	if err.Bound == Maximum {
+		return fmt.Sprintf("must be at most %s", plural(err.Limit, "character"))
+	}
+	return fmt.Sprintf("must be at least %s", plural(err.Limit, "character"))
+}
+
+// MinLength rejects text shorter than a lower bound.
+type MinLength struct {
+	form.Value
+	Min  int
+	Unit Unit
+}
+
+func (v MinLength) From(text string) error {
+	if v.Unit.count(text) < v.Min {
+		return LengthError{Bound: Minimum, Limit: v.Min}
+	}
+	return v.Value.From(text)
+}
+
+func (v MinLength) Unwrap() form.Value {
+	return v.Value
+}
+
+func (v MinLength) Schema(s form.Schema) {
+	form.Describe(v.Value, s)
+	s["minLength"] = v.Min
+}
+
+// MaxLength rejects text longer than an upper bound.
+type MaxLength struct {
+	form.Value
+	Max  int
+	Unit Unit
+}
+
+func (v MaxLength) From(text string) error {
+	if v.Unit.count(text) > v.Max {
+		return LengthError{Bound: Maximum, Limit: v.Max}
+	}
+	return v.Value.From(text)
+}
+
+func (v MaxLength) Unwrap() form.Value {
+	return v.Value
+}
+
+func (v MaxLength) Schema(s form.Schema) {
+	form.Describe(v.Value, s)
+	s["maxLength"] = v.Max
+}
+
+// Pattern rejects text that doesn't match a regular expression.
+// Like JSON Schema, the expression matches anywhere in the text unless anchored.
+type Pattern struct {
+	form.Value
+	Pattern *regexp.Regexp
+	// Message describes the expected text, such as "must be a postcode".
+	// Defaults to naming the expression.
+	Message string
+}
+
+func (v Pattern) From(text string) error {
+	if !v.Pattern.MatchString(text) {
+		if v.Message != "" {
+			return fmt.Errorf("%s", v.Message)
+		}
+		return fmt.Errorf("must match %s", v.Pattern)
+	}
+	return v.Value.From(text)
+}
+
+func (v Pattern) Unwrap() form.Value {
+	return v.Value
+}
+
+func (v Pattern) Schema(s form.Schema) {
+	form.Describe(v.Value, s)
+	s["pattern"] = v.Pattern.String()
+}
+
+// Charset rejects text containing characters outside of the allowed classes, such as
+// `unicode.Letter` and `unicode.Digit`.
+type Charset struct {
+	form.Value
+	Classes []*unicode.RangeTable
+	// Also allows individual characters, such as "-_".
+	Also string
+	// Name describes the allowed characters, such as "letters and digits".
+	Name string
+}
+
+func (v Charset) From(text string) error {
+	for _, r := range text {
+		if !unicode.In(r, v.Classes...) && !strings.ContainsRune(v.Also, r) {
+			name := v.Name
+			if name == "" {
+				name = "permitted characters"
+			}
+			return fmt.Errorf("must contain only %s, not %q", name, r)
+		}
+	}
+	return v.Value.From(text)
+}
+
+func (v Charset) Unwrap() form.Value {
+	return v.Value
+}
+
+// Normalize transforms text before it is parsed, such that equivalent text is stored the same way.
+// Wrap constraints with Normalize for them to check the normalized text.
+type Normalize struct {
+	form.Value
+	// Trim removes leading and trailing space.
+	Trim bool
+	// Collapse replaces runs of space with a single space, and trims.
+	Collapse bool
+	// Fold folds case, for case insensitive text such as usernames.
+	Fold bool
+	// NFC composes characters into Unicode normal form C.
+	NFC bool
+}
+
+func (v Normalize) From(text string) error {
+	return v.Value.From(v.rewrite(text))
+}
+
+func (v Normalize) rewrite(text string) string {
+	if v.NFC {
+		text = norm.NFC.String(text)
+	}
+	if v.Collapse {
+		text = strings.Join(strings.Fields(text), " ")
+	}
+	if v.Trim {
+		text = strings.TrimSpace(text)
+	}
+	if v.Fold {
+		text = cases.Fold().String(text)
+	}
+	return text
+}
+
+func (v Normalize) Unwrap() form.Value {
+	return v.Value
+}
@@ -0,0 +1,123 @@
+package value
+
+import (
+	"regexp"
+	"testing"
+	"unicode"
+
+	"git.sr.ht/~jackmordaunt/gio-planet/form"
+)
+
+func TestLength(t *testing.T) {
+	for _, tt := range []struct {
+		text      string
+		runes     int
+		graphemes int
+	}{
+		{"abc", 3, 3},
+		{"", 0, 0},
+		{"e\u0301te\u0301", 5, 3},
+		{"\U0001f1e6\U0001f1fa\U0001f1f3\U0001f1ff", 4, 2},
+		{"\U0001f44d\U0001f3fd", 2, 1},
+		{"\U0001f469\u200d\U0001f4bb!", 4, 2},
+		{"❤\ufe0f", 2, 1},
+		{"a\r\nb", 4, 3},
+		{"\u1100\u1161\u11a8", 3, 1},
+	} {
+		if got := Runes.count(tt.text); got != tt.runes {
+			t.Fatalf("%q: want %d runes, got %d", tt.text, tt.runes, got)
+		}
+		if got := Graphemes.count(tt.text); got != tt.graphemes {
+			t.Fatalf("%q: want %d graphemes, got %d", tt.text, tt.graphemes, got)
+		}
+	}
+	var s string
+	v := MaxLength{Value: MinLength{Value: Text{Value: &s}, Min: 2, Unit: Graphemes}, Max: 3, Unit: Graphemes}
+	for text, want := range map[string]string{
+		"a":               "must be at least 2 characters",
+		"abcd":            "must be at most 3 characters",
+		"e\u0301te\u0301": "",
+	} {
+		err := v.From(text)
+		if got := errText(err); got != want {
+			t.Fatalf("%q: want %q, got %q", text, want, got)
+		}
+	}
+	if s != "e\u0301te\u0301" {
+		t.Fatalf("want only valid text stored, got %q", s)
+	}
+	if err := (MaxLength{Value: Text{Value: &s}, Max: 1}).From("ab"); err.Error() != "must be at most 1 character" {
+		t.Fatalf("want singular noun, got %q", err)
+	}
+	schema := form.Schema{}
+	form.Describe(v, schema)
+	if schema["minLength"] != 2 || schema["maxLength"] != 3 || schema["type"] != "string" {
+		t.Fatalf("unexpected schema: %v", schema)
+	}
+}
+
+func TestPattern(t *testing.T) {
+	var s string
+	postcode := regexp.MustCompile(`^[0-9]{4}$`)
+	if err := (Pattern{Value: Text{Value: &s}, Pattern: postcode}).From("20000"); errText(err) != "must match ^[0-9]{4}$" {
+		t.Fatalf("want default message, got %v", err)
+	}
+	v := Pattern{Value: Text{Value: &s}, Pattern: postcode, Message: "must be a postcode"}
+	if err := v.From("abc"); errText(err) != "must be a postcode" {
+		t.Fatalf("want custom message, got %v", err)
+	}
+	if err := v.From("2000"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCharset(t *testing.T) {
+	var s string
+	v := Charset{
+		Value:   Text{Value: &s},
+		Classes: []*unicode.RangeTable{unicode.Letter, unicode.Digit},
+		Also:    "-_",
+		Name:    "letters, digits, hyphens and underscores",
+	}
+	if err := v.From("jack_m-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := v.From("jack m"); errText(err) != "must contain only letters, digits, hyphens and underscores, not ' '" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	var s string
+	for _, tt := range []struct {
+		v    Normalize
+		text string
+		want string
+	}{
+		{Normalize{Trim: true}, "  a  b  ", "a  b"},
+		{Normalize{Collapse: true}, " a \t\n b ", "a b"},
+		{Normalize{Fold: true}, "Straße", "strasse"},
+		{Normalize{NFC: true}, "e\u0301", "\u00e9"},
+		{Normalize{}, " A ", " A "},
+	} {
+		tt.v.Value = Text{Value: &s}
+		if err := tt.v.From(tt.text); err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.text, err)
+		}
+		if s != tt.want {
+			t.Fatalf("%q: want %q, got %q", tt.text, tt.want, s)
+		}
+	}
+	// Constraints wrapped by Normalize check the normalized text.
+	v := Normalize{Value: MaxLength{Value: Text{Value: &s}, Max: 1}, NFC: true, Trim: true}
+	if err := v.From(" e\u0301 "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func errText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
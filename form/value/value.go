@@ -15,41 +15,107 @@ import (
 type Int struct {
 	Value   *int
 	Default int
+	// Locale, if set, reads and writes the number as written in the locale.
+	Locale *parse.Locale
 }
 
-func (v Int) To() (string, error) {
-	var n = *v.Value
-	if n == 0 {
-		n = v.Default
+func (v Int) of() Of[int] {
+	if v.Locale != nil {
+		return Of[int]{
+			Value:   v.Value,
+			Default: v.Default,
+			Parse:   v.Locale.ParseInt,
+			Format:  func(n int) string { return v.Locale.FormatInt(n, false) },
+		}
+	}
+	return Of[int]{
+		Value:   v.Value,
+		Default: v.Default,
+		Parse:   parse.Int,
+		Format:  strconv.Itoa,
 	}
-	return strconv.Itoa(n), nil
 }
 
-func (v Int) From(text string) (err error) {
-	*v.Value, err = parse.Int(text)
-	return err
+func (v Int) To() (string, error) {
+	return v.of().To()
+}
+
+func (v Int) From(text string) error {
+	return v.of().From(text)
 }
 
 func (v Int) Clear() {
-	*v.Value = 0
+	v.of().Clear()
+}
+
+func (v Int) Get() (any, error) {
+	if *v.Value == 0 {
+		return v.Default, nil
+	}
+	return *v.Value, nil
+}
+
+func (v Int) Set(data any) error {
+	n, err := v.toNumber(data)
+	if err != nil {
+		return err
+	}
+	*v.Value = int(n)
+	return nil
+}
+
+func (v Int) Schema(s form.Schema) {
+	s["type"] = "integer"
+	if v.Default != 0 {
+		s["default"] = v.Default
+	}
 }
 
 // Float maps text to a floating point number.
 type Float struct {
 	Value *float64
+	// Format controls the textual representation, defaulting to at least two decimal places.
+	Format FloatFormat
+	// Locale determines the separators and signs, defaulting to `parse.DefaultLocale`.
+	Locale *parse.Locale
+}
+
+func (v Float) of() Of[float64] {
+	l := locale(v.Locale)
+	return Of[float64]{
+		Value:  v.Value,
+		Parse:  func(text string) (float64, error) { return v.Format.parse(l, text) },
+		Format: func(n float64) string { return v.Format.format(l, n) },
+	}
 }
 
 func (v Float) To() (string, error) {
-	return strconv.FormatFloat(*v.Value, 'f', 2, 64), nil
+	return v.of().To()
 }
 
-func (v Float) From(text string) (err error) {
-	*v.Value, err = parse.Float(text)
-	return err
+func (v Float) From(text string) error {
+	return v.of().From(text)
 }
 
 func (v Float) Clear() {
-	*v.Value = 0
+	v.of().Clear()
+}
+
+func (v Float) Get() (any, error) {
+	return *v.Value, nil
+}
+
+func (v Float) Set(data any) error {
+	n, err := v.toNumber(data)
+	if err != nil {
+		return err
+	}
+	*v.Value = n
+	return nil
+}
+
+func (v Float) Schema(s form.Schema) {
+	s["type"] = "number"
 }
 
 // Text wraps a text value.
@@ -57,36 +123,115 @@ type Text struct {
 	Value *string
 }
 
+func (v Text) of() Of[string] {
+	return Of[string]{
+		Value:  v.Value,
+		Parse:  func(text string) (string, error) { return text, nil },
+		Format: func(text string) string { return text },
+	}
+}
+
 func (v Text) To() (string, error) {
-	return *v.Value, nil
+	return v.of().To()
 }
 
 func (v Text) From(text string) error {
-	*v.Value = text
-	return nil
+	return v.of().From(text)
 }
 
 func (v Text) Clear() {
-	*v.Value = ""
+	v.of().Clear()
+}
+
+func (v Text) Schema(s form.Schema) {
+	s["type"] = "string"
+}
+
+// Bool maps text to a boolean, written as yes or no. See `parse.Bool` for the accepted words.
+type Bool struct {
+	Value *bool
+}
+
+func (v Bool) of() Of[bool] {
+	return Of[bool]{
+		Value: v.Value,
+		Parse: parse.Bool,
+		Format: func(b bool) string {
+			if b {
+				return "yes"
+			}
+			return "no"
+		},
+	}
+}
+
+func (v Bool) To() (string, error) {
+	return v.of().To()
+}
+
+func (v Bool) From(text string) error {
+	return v.of().From(text)
+}
+
+func (v Bool) Clear() {
+	v.of().Clear()
+}
+
+func (v Bool) Get() (any, error) {
+	return *v.Value, nil
+}
+
+func (v Bool) Set(data any) error {
+	b, ok := data.(bool)
+	if !ok {
+		return fmt.Errorf("want bool, got %T", data)
+	}
+	*v.Value = b
+	return nil
+}
+
+func (v Bool) Schema(s form.Schema) {
+	s["type"] = "boolean"
 }
 
-// Days maps text to 24 hour units of time.
+// Days maps text to a whole number of 24 hour days, written as a bare number such as "3".
+// Other units are accepted where they make whole days, such as "2 weeks". See `Duration`.
+//
+// Clear resets to zero, which displays as empty text.
 type Days struct {
 	Value *time.Duration
 }
 
+const day = 24 * time.Hour
+
+func (v Days) duration() Duration {
+	return Duration{Value: v.Value, Units: []time.Duration{day}, Bare: day, Min: day}
+}
+
 func (v Days) To() (string, error) {
-	days := (*v.Value) / (time.Hour * 24)
-	return strconv.Itoa(int(days)), nil
+	if *v.Value == 0 {
+		return "", nil
+	}
+	return strconv.FormatFloat(float64(*v.Value)/float64(day), 'f', -1, 64), nil
 }
 
-func (v Days) From(text string) (err error) {
-	*v.Value, err = parse.Day(text)
-	return err
+func (v Days) From(text string) error {
+	d, err := parse.DurationIn(text, day)
+	if err != nil || d%day != 0 {
+		return fmt.Errorf(`must be a whole number of days, such as "3"`)
+	}
+	if d < day {
+		return fmt.Errorf("must be at least 1 day")
+	}
+	return v.duration().From(text)
 }
 
 func (v Days) Clear() {
-	*v.Value = time.Hour * 24
+	v.duration().Clear()
+}
+
+func (v Days) Schema(s form.Schema) {
+	s["type"] = "string"
 }
 
 // Required errors when the field is empty.
@@ -101,25 +246,155 @@ func (v Required) From(text string) error {
 	return v.Value.From(text)
 }
 
+func (v Required) ParseNumber(text string) (float64, error) {
+	if len(strings.TrimSpace(text)) == 0 {
+		return 0, fmt.Errorf("required")
+	}
+	return parseNumber(v.Value, text)
+}
+
+func (v Required) Get() (any, error) {
+	return getData(v.Value)
+}
+
+// Set rejects missing data and blank strings, such as an unselected option.
+func (v Required) Set(data any) error {
+	typed, err := typedValue(v.Value)
+	if err != nil {
+		return err
+	}
+	if missing(data) {
+		return fmt.Errorf("required")
+	}
+	return typed.Set(data)
+}
+
+func (v Required) toNumber(data any) (float64, error) {
+	if missing(data) {
+		return 0, fmt.Errorf("required")
+	}
+	return dataNumber(v.Value, data)
+}
+
+// missing reports whether structured data is absent, such as nil or a blank string.
+func missing(data any) bool {
+	text, ok := data.(string)
+	return data == nil || ok && len(strings.TrimSpace(text)) == 0
+}
+
+func (v Required) Required() bool {
+	return true
+}
+
+func (v Required) Unwrap() form.Value {
+	return v.Value
+}
+
+// typedValue returns the wrapped value of a wrapper that exchanges structured data.
+func typedValue(v form.Value) (form.TypedValue, error) {
+	typed, ok := v.(form.TypedValue)
+	if !ok {
+		return nil, fmt.Errorf("want typed value, got %T", v)
+	}
+	return typed, nil
+}
+
+// getData returns the structured data of the wrapped value of a wrapper.
+func getData(v form.Value) (any, error) {
+	typed, err := typedValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return typed.Get()
+}
+
 // Date maps text to a structured date.
 type Date struct {
 	Value   *time.Time
 	Default time.Time
+	// Layouts accepted when parsing, in order of preference. Defaults to `parse.DMY`.
+	// See `parse.DateParser` for the layout syntax.
+	Layouts []string
+	// Display is the layout used to display the date. Defaults to the first layout.
+	Display string
+	// Relative also accepts dates relative to today, such as "tomorrow" or "+3d".
+	// See `parse.Relative`.
+	Relative bool
+	// Now is the clock that relative dates and Clear are evaluated against.
+	// Defaults to `time.Now`.
+	Now func() time.Time
 }
 
-func (v Date) To() (string, error) {
-	var t = *v.Value
-	if t.IsZero() {
-		t = v.Default
+func (v Date) of() Of[time.Time] {
+	display := v.display()
+	return Of[time.Time]{
+		Value:   v.Value,
+		Default: v.Default,
+		Parse:   v.parse,
+		Format:  func(t time.Time) string { return parse.FormatDate(t, display) },
+		Unset:   time.Time.IsZero,
+		Reset:   v.now,
+	}
+}
+
+func (v Date) parse(text string) (time.Time, error) {
+	t, err := parse.DateParser{Layouts: v.Layouts}.Parse(text)
+	if err != nil && v.Relative {
+		if t, err := parse.Relative(text, v.now()); err == nil {
+			return t, nil
+		}
 	}
-	return fmt.Sprintf("%d/%d/%d", t.Day(), t.Month(), t.Year()), nil
+	return t, err
 }
 
-func (v Date) From(text string) (err error) {
-	*v.Value, err = parse.Date(text)
-	return err
+func (v Date) now() time.Time {
+	return clock(v.Now)
+}
+
+// display resolves the layout used to display the date.
+func (v Date) display() string {
+	switch {
+	case v.Display != "":
+		return v.Display
+	case len(v.Layouts) > 0:
+		return v.Layouts[0]
+	default:
+		return parse.DMY
+	}
+}
+
+func (v Date) To() (string, error) {
+	return v.of().To()
+}
+
+func (v Date) From(text string) error {
+	return v.of().From(text)
 }
 
 func (v Date) Clear() {
-	*v.Value = time.Now()
+	v.of().Clear()
+}
+
+func (v Date) Schema(s form.Schema) {
+	s["type"] = "string"
+	if v.iso() {
+		s["format"] = "date"
+	}
+	if !v.Default.IsZero() {
+		s["default"] = parse.FormatDate(v.Default, v.display())
+	}
+}
+
+// iso reports whether dates are only accepted and displayed as ISO 8601, such that the JSON
+// Schema "date" format describes the text.
+func (v Date) iso() bool {
+	if v.Relative || len(v.Layouts) == 0 || v.display() != parse.ISO {
+		return false
+	}
+	for _, layout := range v.Layouts {
+		if layout != parse.ISO {
+			return false
+		}
+	}
+	return true
 }